@@ -0,0 +1,282 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const additionalPropertiesSrc = `package _
+
+func marshalAdditionalProperties[T any](data []byte, additional map[string]T) ([]byte, error) {
+	if len(additional) == 0 {
+		return data, nil
+	}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range additional {
+		if _, exists := obj[key]; exists {
+			continue
+		}
+		obj[key], err = json.Marshal(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "additional property %s", key)
+		}
+	}
+	return json.Marshal(obj)
+}
+
+func unmarshalAdditionalProperties[T any](data []byte, declared ...string) (map[string]T, error) {
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range declared {
+		delete(obj, key)
+	}
+	if len(obj) == 0 {
+		return nil, nil
+	}
+	additional := make(map[string]T, len(obj))
+	for key, raw := range obj {
+		var value T
+		err = json.Unmarshal(raw, &value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "additional property %s", key)
+		}
+		additional[key] = value
+	}
+	return additional, nil
+}
+`
+
+// isMapSchema reports whether schema is generated as map[string]T: it only
+// declares additionalProperties.
+func isMapSchema(schema *openapi3.Schema) bool {
+	additional := schema.AdditionalProperties
+	return (additional.Schema != nil || additional.Has != nil && *additional.Has) && len(schema.Properties) == 0
+}
+
+// hasUntypedAdditionalProperties reports whether the additionalProperties of
+// schema accept any value: `additionalProperties: true` or `{}`. They are kept
+// as decoded, in a map[string]any.
+func hasUntypedAdditionalProperties(schema *openapi3.Schema) bool {
+	additional := schema.AdditionalProperties
+	if additional.Schema == nil {
+		return additional.Has != nil && *additional.Has
+	}
+	value := additional.Schema.Value
+
+	return additional.Schema.Ref == "" && (value.Type == nil || len(*value.Type) == 0) &&
+		len(value.Properties) == 0 && value.Items == nil && value.AdditionalProperties.Schema == nil &&
+		len(value.AllOf) == 0 && len(value.OneOf) == 0 && len(value.AnyOf) == 0
+}
+
+// ProcessAdditionalProperties generates the value type of additionalProperties
+// and returns its name.
+func (g *Generator) ProcessAdditionalProperties(modelName string, schema *openapi3.SchemaRef) (string, error) {
+	const op = "generator.ProcessAdditionalProperties"
+	if schema.Ref == "" {
+		switch {
+		case schema.Value.Type.Permits(openapi3.TypeObject):
+			err := g.ProcessSchema(modelName+"Value", schema)
+			if err != nil {
+				return "", errors.Wrap(err, op)
+			}
+		case schema.Value.Type.Permits(openapi3.TypeArray):
+			err := g.ProcessSchema(modelName+"Value", schema)
+			if err != nil {
+				return "", errors.Wrap(err, op)
+			}
+		}
+	}
+
+	valueType, err := g.GetFieldTypeFromSchema(modelName, "Value", schema)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+
+	return valueType, nil
+}
+
+// AddAdditionalPropertiesJSON adds JSON methods moving the properties that are
+// not declared in the schema from and to the AdditionalProperties field.
+func (g *Generator) AddAdditionalPropertiesJSON(modelName string, valueType string, declared []string) {
+	g.AddAdditionalPropertiesHelpers()
+
+	plainType := &ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: I("plain"),
+					Type: I(modelName),
+				},
+			},
+		},
+	}
+
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, Func("MarshalJSON",
+		Field("s", I(modelName), ""),
+		nil,
+		[]*ast.Field{
+			Field("", &ast.ArrayType{Elt: I("byte")}, ""),
+			Field("", I("error"), ""),
+		},
+		[]ast.Stmt{
+			plainType,
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("data"), I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: Sel(I("json"), "Marshal"),
+						Args: []ast.Expr{
+							&ast.CallExpr{Fun: I("plain"), Args: []ast.Expr{I("s")}},
+						},
+					},
+				},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
+			},
+			Ret1(&ast.CallExpr{
+				Fun:  I("marshalAdditionalProperties"),
+				Args: []ast.Expr{I("data"), Sel(I("s"), "AdditionalProperties")},
+			}),
+		},
+	))
+
+	args := []ast.Expr{I("data")}
+	for _, name := range declared {
+		args = append(args, Str(name))
+	}
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, Func("UnmarshalJSON",
+		Field("s", Star(I(modelName)), ""),
+		[]*ast.Field{
+			Field("data", &ast.ArrayType{Elt: I("byte")}, ""),
+		},
+		[]*ast.Field{
+			Field("", I("error"), ""),
+		},
+		[]ast.Stmt{
+			plainType,
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: Sel(I("json"), "Unmarshal"),
+						Args: []ast.Expr{
+							I("data"),
+							&ast.CallExpr{
+								Fun:  &ast.ParenExpr{X: Star(I("plain"))},
+								Args: []ast.Expr{I("s")},
+							},
+						},
+					},
+				},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{Sel(I("s"), "AdditionalProperties"), I("err")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun:  &ast.IndexExpr{X: I("unmarshalAdditionalProperties"), Index: I(valueType)},
+						Args: args,
+					},
+				},
+			},
+			Ret1(I("err")),
+		},
+	))
+}
+
+func (g *Generator) AddAdditionalPropertiesHelpers() {
+	if g.SchemasFile.hasAdditionalPropertiesHelpers {
+		return
+	}
+	g.SchemasFile.hasAdditionalPropertiesHelpers = true
+	g.AddSchemasImport("encoding/json")
+	g.AddSchemasImport("github.com/go-faster/errors")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", additionalPropertiesSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, file.Decls...)
+}
+
+// additionalPropertiesValidateStmts checks the properties of obj that are not
+// declared in schema: they are rejected when the schema is closed, or passed to
+// validateFunc otherwise.
+func (g *Generator) additionalPropertiesValidateStmts(schema *openapi3.Schema, closed bool, validateFunc ast.Expr) []ast.Stmt {
+	var stmts []ast.Stmt
+	var loopBody []ast.Stmt
+	if len(schema.Properties) > 0 {
+		declaredFieldsElts := make([]ast.Expr, 0, len(schema.Properties))
+		for _, fieldName := range sortedKeys(schema.Properties) {
+			declaredFieldsElts = append(declaredFieldsElts, &ast.KeyValueExpr{
+				Key:   Str(fieldName),
+				Value: I("true"),
+			})
+		}
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: []ast.Expr{I("declaredFields")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CompositeLit{
+					Type: &ast.MapType{
+						Key:   I("string"),
+						Value: I("bool"),
+					},
+					Elts: declaredFieldsElts,
+				},
+			},
+		})
+		loopBody = append(loopBody, &ast.IfStmt{
+			Cond: &ast.IndexExpr{X: I("declaredFields"), Index: I("field")},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.CONTINUE}}},
+		})
+	}
+
+	loop := &ast.RangeStmt{
+		Key: I("field"),
+		Tok: token.DEFINE,
+		X:   I("obj"),
+	}
+	if closed {
+		loopBody = append(loopBody, addFieldError(I("field"), "unknown", Str("unknown field")))
+	} else {
+		g.AddContainsNullIfNeeded()
+		loop.Value = I("val")
+		loopBody = append(loopBody,
+			&ast.IfStmt{
+				Cond: &ast.CallExpr{
+					Fun:  I("containsNull"),
+					Args: []ast.Expr{I("val")},
+				},
+				Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.CONTINUE}}},
+			},
+			mergeFieldErrors(I("field"), &ast.CallExpr{
+				Fun:  validateFunc,
+				Args: []ast.Expr{I("val")},
+			}),
+		)
+	}
+	loop.Body = &ast.BlockStmt{List: loopBody}
+
+	return append(stmts, loop)
+}
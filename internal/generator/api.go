@@ -16,13 +16,19 @@ import (
 	"golang.org/x/text/language"
 )
 
-const directoryPermissions = 0o755
+const (
+	directoryPermissions = 0o755
+	filePermissions      = 0o644
+)
 
 type Generator struct {
 	Opts *options.Options
 
 	SchemasFile  *SchemasFile
 	HandlersFile *HandlersFile
+	ClientFile   *ClientFile
+	MocksFile    *MocksFile
+	ImplFile     *ImplFile
 	yaml         *openapi3.T
 
 	// strings
@@ -57,26 +63,36 @@ func (g *Generator) WriteToOutput(modelsOutput io.Writer, handlersOutput io.Writ
 	return nil
 }
 
-func (g *Generator) Gen() {
+func (g *Generator) Gen() error {
 	const op = "generator.Generate"
 
 	// one time
 	g.InitHandlerFields(g.PackageName)
 
+	err := g.CollectCustomValidators()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
 	if g.yaml.Paths != nil && len(g.yaml.Paths.Map()) > 0 {
-		err := g.ProcessPaths(g.yaml.Paths)
+		g.HandlersFile.basePaths, err = basePaths(g.yaml.Servers)
 		if err != nil {
-			panic(errors.Wrap(err, op))
+			return errors.Wrap(err, op)
+		}
+		err = g.ProcessPaths(g.yaml.Paths)
+		if err != nil {
+			return errors.Wrap(err, op)
 		}
 	}
 
 	if g.yaml.Components != nil && g.yaml.Components.Schemas != nil {
 		err := g.ProcessSchemas(g.yaml.Components.Schemas)
 		if err != nil {
-			panic(errors.Wrap(err, op))
+			return errors.Wrap(err, op)
 		}
 	}
 
+	return nil
 }
 
 func (g *Generator) GetModelName(yamlFilePath string) string {
@@ -112,13 +128,17 @@ func (g *Generator) PrepareAndRead(reader io.Reader) error {
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	err = g.yaml.Validate(ctx)
+	// patterns are compiled by CollectCustomValidators, which reports RE2 incompatibilities clearly
+	err = g.yaml.Validate(ctx, openapi3.DisableSchemaPatternValidation())
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
 
 	g.NewSchemasFile()
 	g.NewHandlersFile()
+	g.NewClientFile()
+	g.NewMocksFile()
+	g.NewImplFile()
 
 	return nil
 }
@@ -154,9 +174,9 @@ func (g *Generator) PrepareFiles() error {
 }
 
 func (g *Generator) GenerateFiles() error {
-	g.Gen()
-	return nil
+	return g.Gen()
 }
+
 func (g *Generator) WriteOutFiles() error {
 	const op = "generator.WriteOutFiles"
 
@@ -178,6 +198,38 @@ func (g *Generator) WriteOutFiles() error {
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	err = g.WriteImplFile()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if !g.HasClient() {
+		return nil
+	}
+
+	clientOutput, err := os.Create(path.Join(handlersPath, "client.go"))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer clientOutput.Close()
+
+	err = g.WriteClientToOutput(clientOutput)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if !g.HasMocks() {
+		return nil
+	}
+
+	mocksOutput, err := os.Create(path.Join(handlersPath, "mocks.go"))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer mocksOutput.Close()
+
+	err = g.WriteMocksToOutput(mocksOutput)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
 	return nil
 }
 
@@ -0,0 +1,312 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const applicationOctetStreamCT = "application/octet-stream"
+
+const binaryBodyModelSrc = `package _
+
+// BinaryBody is a request or response body of format binary, sent as is.
+type BinaryBody struct {
+	ContentType   string
+	ContentLength int64
+	Filename      string
+	Content       io.ReadCloser
+}
+`
+
+// binarySrc refers to the models package as models.
+const binarySrc = `package _
+
+// readBinaryBody returns content, a body of format binary of length bytes,
+// with the media type and the filename of header.
+func readBinaryBody(header http.Header, content io.ReadCloser, length int64) *models.BinaryBody {
+	body := &models.BinaryBody{ContentLength: length, Content: content}
+	body.ContentType, _, _ = mime.ParseMediaType(header.Get("Content-Type"))
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		body.Filename = params["filename"]
+	}
+	return body
+}
+
+// setBinaryBodyHeaders sets the Content-Type of body, contentType unless it has
+// its own, its Content-Length when known, and its filename as an attachment.
+func setBinaryBodyHeaders(header http.Header, body *models.BinaryBody, contentType string) {
+	if body.ContentType != "" {
+		contentType = body.ContentType
+	}
+	header.Set("Content-Type", contentType)
+	if body.ContentLength > 0 {
+		header.Set("Content-Length", strconv.FormatInt(body.ContentLength, 10))
+	}
+	if body.Filename != "" {
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": body.Filename}))
+	}
+}
+
+// writeBinaryBody streams the content of body to w.
+func writeBinaryBody(w io.Writer, body *models.BinaryBody) error {
+	if body.Content == nil {
+		return nil
+	}
+	_, err := io.Copy(w, body.Content)
+	return err
+}
+
+// closeBinaryBody closes the content of body, if any, whether it was written or
+// not.
+func closeBinaryBody(body *models.BinaryBody) {
+	if body != nil && body.Content != nil {
+		body.Content.Close()
+	}
+}
+`
+
+const binaryClientSrc = `package _
+
+// setBinaryBody sends content as is, with its length when known and its
+// filename as an attachment when set.
+func (cr *clientRequest) setBinaryBody(content io.Reader, contentType string, length int64, filename string) {
+	cr.raw, cr.contentType, cr.rawLength = content, contentType, length
+	if filename != "" {
+		if cr.header == nil {
+			cr.header = make(http.Header)
+		}
+		cr.header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+}
+`
+
+// isBinaryContent reports whether bodies of the media type are sent as is: it
+// is not JSON and its schema is a string of format binary.
+func isBinaryContent(contentType string, content *openapi3.MediaType) bool {
+	return !isJSONMediaType(contentType) && content != nil && content.Schema != nil &&
+		isBinarySchema(content.Schema.Value)
+}
+
+// AddBinaryBodyModel adds the BinaryBody type binary bodies are read into to the
+// models.
+func (g *Generator) AddBinaryBodyModel() {
+	if g.SchemasFile.hasBinaryBody {
+		return
+	}
+	g.SchemasFile.hasBinaryBody = true
+	g.AddSchemasImport("io")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", binaryBodyModelSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, file.Decls...)
+}
+
+// binaryBodyType returns the type of binary bodies in the handlers and the
+// client file.
+func (g *Generator) binaryBodyType() ast.Expr {
+	g.AddBinaryBodyModel()
+	g.HandlersFile.hasBinaryBodies = true
+
+	return Sel(I(g.GetCurrentModelsPackage()), "BinaryBody")
+}
+
+// AddBinaryDecls adds the helpers reading and writing binary bodies, when an
+// operation has one.
+func (g *Generator) AddBinaryDecls() {
+	if !g.HandlersFile.hasBinaryBodies {
+		return
+	}
+	for _, path := range []string{"io", "mime", "net/http", "strconv"} {
+		g.AddHandlersImport(path)
+	}
+
+	src := strings.ReplaceAll(binarySrc, "models.", g.GetCurrentModelsPackage()+".")
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// AddBinaryClientDecls adds the helper sending binary request bodies to the
+// client.
+func (g *Generator) AddBinaryClientDecls() {
+	if g.ClientFile.hasBinary {
+		return
+	}
+	g.ClientFile.hasBinary = true
+	for _, path := range []string{"io", "mime", "net/http"} {
+		g.AddClientImport(path)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", binaryClientSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.ClientFile.extraDecls = append(g.ClientFile.extraDecls, file.Decls...)
+}
+
+// AddParseBinaryRequestBodyMethod adds parse<Op>RequestBody returning the body
+// of the request as is, nil for an optional body left empty.
+func (g *Generator) AddParseBinaryRequestBodyMethod(baseName string, required bool) {
+	var body []ast.Stmt
+	if !required {
+		body = append(body, &ast.IfStmt{
+			Cond: Eq(Sel(I("r"), "ContentLength"), intLit("0")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("nil"))}},
+		})
+	}
+	body = append(body, Ret2(&ast.CallExpr{
+		Fun:  I("readBinaryBody"),
+		Args: []ast.Expr{Sel(I("r"), "Header"), Sel(I("r"), "Body"), Sel(I("r"), "ContentLength")},
+	}, I("nil")))
+
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func(
+		"parse"+baseName+"RequestBody",
+		Field("h", Star(I("Handler")), ""),
+		[]*ast.Field{
+			Field("r", Star(Sel(I("http"), "Request")), ""),
+		},
+		[]*ast.Field{
+			Field("", Star(g.binaryBodyType()), ""),
+			Field("", I("error"), ""),
+		},
+		body,
+	))
+}
+
+// clientBinaryStmts returns the statements sending body as is, in its own
+// content type or the one of the spec.
+func (g *Generator) clientBinaryStmts(contentType string, body ast.Expr) []ast.Stmt {
+	g.AddBinaryClientDecls()
+	g.AddClientImport("cmp")
+	if star, ok := body.(*ast.StarExpr); ok {
+		// the fields are selected through the pointer
+		body = star.X
+	}
+
+	return []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+		Fun: Sel(I("cr"), "setBinaryBody"),
+		Args: []ast.Expr{
+			Sel(body, "Content"),
+			&ast.CallExpr{Fun: Sel(I("cmp"), "Or"), Args: []ast.Expr{Sel(body, "ContentType"), Str(contentType)}},
+			Sel(body, "ContentLength"),
+			Sel(body, "Filename"),
+		},
+	}}}
+}
+
+// binaryContentType returns the content type of a binary response body without
+// one of its own: the media type of the spec, unless it is a media range.
+func binaryContentType(contentType string) string {
+	if strings.Contains(contentType, "*") {
+		return applicationOctetStreamCT
+	}
+
+	return contentType
+}
+
+// closeBinaryBodiesStmts returns the statements closing, or deferring the close
+// of, every binary body the handler set in the response of the operation, so
+// that none is left open when the response is rejected or another body is
+// negotiated.
+func closeBinaryBodiesStmts(codes []string, operation *openapi3.Operation, deferred bool) []ast.Stmt {
+	var stmts []ast.Stmt
+	for _, code := range codes {
+		response := operation.Responses.Value(code)
+		responseField := Sel(I("response"), "Response"+code)
+		contentTypes := sortedKeys(response.Value.Content)
+		var closes []ast.Stmt
+		for _, contentType := range contentTypes {
+			if !isBinaryContent(contentType, response.Value.Content[contentType]) {
+				continue
+			}
+			var body ast.Expr = Sel(responseField, responseBodyField(contentType, contentTypes))
+			if len(contentTypes) == 1 {
+				body = Amp(body)
+			}
+			call := &ast.CallExpr{Fun: I("closeBinaryBody"), Args: []ast.Expr{body}}
+			if deferred {
+				closes = append(closes, &ast.DeferStmt{Call: call})
+			} else {
+				closes = append(closes, &ast.ExprStmt{X: call})
+			}
+		}
+		if len(closes) > 0 {
+			stmts = append(stmts, &ast.IfStmt{
+				Cond: Ne(responseField, I("nil")),
+				Body: &ast.BlockStmt{List: closes},
+			})
+		}
+	}
+
+	return stmts
+}
+
+// closeFailedResponseStmts returns the statement closing the binary bodies of a
+// response the handler returned along with an error, which is never written,
+// nil when the responses of the operation have none.
+func closeFailedResponseStmts(codes []string, operation *openapi3.Operation) []ast.Stmt {
+	closes := closeBinaryBodiesStmts(codes, operation, false)
+	if len(closes) == 0 {
+		return nil
+	}
+
+	return []ast.Stmt{&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  Ne(I("err"), I("nil")),
+			Op: token.LAND,
+			Y:  Ne(I("response"), I("nil")),
+		},
+		Body: &ast.BlockStmt{List: closes},
+	}}
+}
+
+// addressOf returns the address of field, or the pointer it dereferences.
+func addressOf(field ast.Expr) ast.Expr {
+	if star, ok := field.(*ast.StarExpr); ok {
+		return star.X
+	}
+
+	return Amp(field)
+}
+
+// readBinaryResponseStmts returns the statements reading the response body, of
+// format binary, into field. The client buffers it, so that the response can be
+// closed once decoded.
+func (g *Generator) readBinaryResponseStmts(field ast.Expr) []ast.Stmt {
+	for _, path := range []string{"bytes", "io"} {
+		g.AddClientImport(path)
+	}
+
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("data"), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("io"), "ReadAll"), Args: []ast.Expr{Sel(I("resp"), "Body")}}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{field},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{Star(&ast.CallExpr{
+				Fun: I("readBinaryBody"),
+				Args: []ast.Expr{
+					Sel(I("resp"), "Header"),
+					&ast.CallExpr{Fun: Sel(I("io"), "NopCloser"), Args: []ast.Expr{
+						&ast.CallExpr{Fun: Sel(I("bytes"), "NewReader"), Args: []ast.Expr{I("data")}},
+					}},
+					&ast.CallExpr{Fun: I("int64"), Args: []ast.Expr{
+						&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I("data")}},
+					}},
+				},
+			})},
+		},
+		mergeFieldErrors(Str("body"), I("err")),
+	}
+}
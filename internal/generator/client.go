@@ -0,0 +1,977 @@
+package generator
+
+import (
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const clientSrc = `package _
+
+// RequestEditorFn changes a request before the client sends it.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Client calls the operations of the API served at its base URL.
+type Client struct {
+	baseURL        string
+	httpClient     *http.Client
+	requestEditors []RequestEditorFn
+}
+
+type ClientOption func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) { c.requestEditors = append(c.requestEditors, fn) }
+}
+
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// UnexpectedStatusError is returned for a response status the operation does not declare.
+type UnexpectedStatusError struct {
+	Operation  string
+	StatusCode int
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status code %d", e.Operation, e.StatusCode)
+}
+
+var responseValidator = newResponseValidator()
+
+// ignoreUnknownFields drops the failures of err on properties a schema does not
+// declare: a client reads the properties it knows of, as a server may add
+// properties to its responses before its clients are regenerated.
+func ignoreUnknownFields(err error) error {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	known := &ValidationError{}
+	for _, field := range validationErr.Errors {
+		if field.Rule != "unknown" {
+			known.Errors = append(known.Errors, field)
+		}
+	}
+	return known.Err()
+}
+
+type clientRequest struct {
+	method  string
+	path    string
+	query   url.Values
+	header  http.Header
+	cookies []*http.Cookie
+	body    any
+	form    url.Values
+	raw     io.Reader
+	// rawLength is the length of raw when known.
+	rawLength int64
+	// contentType replaces the content type of the body, like
+	// application/merge-patch+json for a JSON body.
+	contentType string
+}
+
+func (c *Client) do(ctx context.Context, cr clientRequest) (*http.Response, error) {
+	var body io.Reader
+	var contentType string
+	switch {
+	case cr.raw != nil:
+		body = cr.raw
+	case cr.form != nil:
+		body = strings.NewReader(cr.form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	case cr.body != nil:
+		data, err := json.Marshal(cr.body)
+		if err != nil {
+			return nil, errors.Wrap(err, "encode request body")
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+	if cr.contentType != "" {
+		contentType = cr.contentType
+	}
+	target := c.baseURL + cr.path
+	if len(cr.query) > 0 {
+		target += "?" + cr.query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, cr.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if cr.rawLength > 0 {
+		req.ContentLength = cr.rawLength
+	}
+	for key, values := range cr.header {
+		req.Header[key] = values
+	}
+	for _, cookie := range cr.cookies {
+		req.AddCookie(cookie)
+	}
+	for _, editor := range c.requestEditors {
+		err = editor(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.httpClient.Do(req)
+}
+
+// decodeResponseHeaders reads the named headers into v the way the server
+// writes them: through their JSON encoding.
+func decodeResponseHeaders(header http.Header, v any, names ...string) error {
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			values[name] = value
+		}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+`
+
+type ClientFile struct {
+	packageImports []string
+	methodDecls    []ast.Decl
+	extraDecls     []ast.Decl
+	hasMultipart   bool
+	hasBinary      bool
+}
+
+func (g *Generator) NewClientFile() {
+	g.ClientFile = &ClientFile{}
+}
+
+func (g *Generator) AddClientImport(path string) {
+	if slices.Contains(g.ClientFile.packageImports, path) {
+		return
+	}
+	g.ClientFile.packageImports = append(g.ClientFile.packageImports, path)
+}
+
+// HasClient reports whether the spec declares operations to generate a client for.
+func (g *Generator) HasClient() bool {
+	return len(g.ClientFile.methodDecls) > 0
+}
+
+func (g *Generator) WriteClientToOutput(output io.Writer) error {
+	const op = "generator.ClientFile.WriteToOutput"
+	_, err := output.Write([]byte("// Code generated by github.com/sintoniastrategy/validgo-gen; DO NOT EDIT.\n\n"))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	file := g.GenerateClientFile()
+	err = format.Node(output, token.NewFileSet(), file)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (g *Generator) GenerateClientFile() *ast.File {
+	for _, path := range []string{
+		"bytes", "context", "encoding/json", "fmt", "io", "net/http", "net/url", "strings",
+		"github.com/go-faster/errors",
+	} {
+		g.AddClientImport(path)
+	}
+	staticFile, err := parser.ParseFile(token.NewFileSet(), "", clientSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	newResponseValidator := g.newResponseValidatorDecl()
+
+	importSpecs, declSpecs := g.GenerateImportsSpecs(g.ClientFile.packageImports)
+	file := &ast.File{
+		Name:    g.HandlersFile.packageName,
+		Imports: importSpecs,
+		Decls: []ast.Decl{&ast.GenDecl{
+			Tok:   token.IMPORT,
+			Specs: declSpecs,
+		}},
+	}
+	file.Decls = append(file.Decls, staticFile.Decls...)
+	file.Decls = append(file.Decls, newResponseValidator)
+	file.Decls = append(file.Decls, g.ClientFile.methodDecls...)
+	file.Decls = append(file.Decls, g.ClientFile.extraDecls...)
+
+	return file
+}
+
+// newResponseValidatorDecl returns newResponseValidator, which configures the
+// validator of responses the way NewHandler configures the one of requests.
+func (g *Generator) newResponseValidatorDecl() *ast.FuncDecl {
+	g.AddClientImport("github.com/go-playground/validator/v10")
+	body := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("v")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("validator"), "New"),
+				Args: []ast.Expr{&ast.CallExpr{Fun: Sel(I("validator"), "WithRequiredStructEnabled")}},
+			}},
+		},
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(I("v"), "RegisterTagNameFunc"),
+			Args: []ast.Expr{I("jsonTagName")},
+		}},
+	}
+	if len(g.HandlersFile.patterns) > 0 {
+		body = append(body, &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  I("registerPatternValidators"),
+			Args: []ast.Expr{I("v")},
+		}})
+	}
+	if g.HandlersFile.hasMultipleOf {
+		body = append(body, &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  I("registerMultipleOfValidator"),
+			Args: []ast.Expr{I("v")},
+		}})
+	}
+
+	return Func("newResponseValidator", nil, nil,
+		FieldA(Field("", Star(Sel(I("validator"), "Validate")), "")),
+		append(body, Ret1(I("v"))),
+	)
+}
+
+// AddClientMethod generates the Client method calling the operation. Parameters
+// are serialized the way the generated parsers read them, and the response is
+// decoded into the field of its status code.
+func (g *Generator) AddClientMethod(baseName string, method string, pathName string, operation *openapi3.Operation) error {
+	const op = "generator.AddClientMethod"
+	g.AddClientImport(g.ModelsImportPath)
+	models := g.GetCurrentModelsPackage()
+	request := I("request")
+
+	pathParams := g.GetOperationParamsByType(operation, openapi3.ParameterInPath)
+	queryParams := g.GetOperationParamsByType(operation, openapi3.ParameterInQuery)
+	headerParams := g.GetOperationParamsByType(operation, openapi3.ParameterInHeader)
+	cookieParams := g.GetOperationParamsByType(operation, openapi3.ParameterInCookie)
+
+	path, err := g.clientPathExpr(pathName, pathParams)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	requestElts := []ast.Expr{
+		&ast.KeyValueExpr{Key: I("method"), Value: Sel(I("http"), "Method"+method)},
+		&ast.KeyValueExpr{Key: I("path"), Value: path},
+	}
+	if len(queryParams) > 0 {
+		requestElts = append(requestElts, &ast.KeyValueExpr{
+			Key:   I("query"),
+			Value: &ast.CompositeLit{Type: Sel(I("url"), "Values")},
+		})
+	}
+	if len(headerParams) > 0 {
+		requestElts = append(requestElts, &ast.KeyValueExpr{
+			Key:   I("header"),
+			Value: &ast.CompositeLit{Type: Sel(I("http"), "Header")},
+		})
+	}
+	body := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{I("cr")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.CompositeLit{Type: I("clientRequest"), Elts: requestElts}},
+	}}
+
+	for _, param := range queryParams {
+		stmts, err := g.clientQueryParam(Sel(request, "Query"), param.Value)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		body = append(body, stmts...)
+	}
+	for _, param := range headerParams {
+		if g.Opts.AllowRemoteAddrParam && param.Value.Name == "Remote-Addr" && param.Value.Schema.Value.Format == "remote-addr" {
+			// the server reads it from the connection
+			continue
+		}
+		field := Sel(Sel(request, "Headers"), FormatGoLikeIdentifier(param.Value.Name))
+		body = append(body, g.clientOptional(field, param.Value.Required, func(value ast.Expr) []ast.Stmt {
+			return []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  Sel(Sel(I("cr"), "header"), "Set"),
+				Args: []ast.Expr{Str(param.Value.Name), g.clientFormatExpr(param.Value.Schema, value, true)},
+			}}}
+		})...)
+	}
+	for _, param := range cookieParams {
+		field := Sel(Sel(request, "Cookies"), FormatGoLikeIdentifier(param.Value.Name))
+		body = append(body, g.clientOptional(field, param.Value.Required, func(value ast.Expr) []ast.Stmt {
+			cookie := Amp(&ast.CompositeLit{
+				Type: Sel(I("http"), "Cookie"),
+				Elts: []ast.Expr{
+					&ast.KeyValueExpr{Key: I("Name"), Value: Str(param.Value.Name)},
+					&ast.KeyValueExpr{Key: I("Value"), Value: g.clientFormatExpr(param.Value.Schema, value, true)},
+				},
+			})
+			return []ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{Sel(I("cr"), "cookies")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  I("append"),
+					Args: []ast.Expr{Sel(I("cr"), "cookies"), cookie},
+				}},
+			}}
+		})...)
+	}
+	contentTypes, err := requestContentTypes(baseName, operation)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if len(contentTypes) > 1 {
+		// the body is sent in the content type of the first body field set
+		var clauses []ast.Stmt
+		for _, contentType := range contentTypes {
+			content := operation.RequestBody.Value.Content[contentType]
+			if content.Schema == nil {
+				continue
+			}
+			field := Sel(request, bodyVariant(contentType)+"Body")
+			stmts, err := g.clientBodyStmts(baseName, contentType, field, content)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			clauses = append(clauses, &ast.CaseClause{
+				List: []ast.Expr{Ne(field, I("nil"))},
+				Body: stmts,
+			})
+		}
+		if len(clauses) > 0 {
+			body = append(body, &ast.SwitchStmt{Body: &ast.BlockStmt{List: clauses}})
+		}
+	} else if len(contentTypes) == 1 {
+		var setBody []ast.Stmt
+		content := operation.RequestBody.Value.Content[contentTypes[0]]
+		if content.Schema != nil {
+			setBody, err = g.clientBodyStmts(baseName, contentTypes[0], Sel(request, "Body"), content)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+		switch {
+		case len(setBody) == 0:
+		case operation.RequestBody.Value.Required:
+			body = append(body, setBody...)
+		default:
+			body = append(body, &ast.IfStmt{
+				Cond: Ne(Sel(request, "Body"), I("nil")),
+				Body: &ast.BlockStmt{List: setBody},
+			})
+		}
+	}
+
+	body = append(body,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("resp"), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("c"), "do"),
+				Args: []ast.Expr{I("ctx"), I("cr")},
+			}},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), &ast.CallExpr{
+				Fun:  Sel(I("errors"), "Wrap"),
+				Args: []ast.Expr{I("err"), Str(baseName)},
+			})}},
+		},
+		&ast.DeferStmt{Call: &ast.CallExpr{Fun: Sel(Sel(I("resp"), "Body"), "Close")}},
+		Ret1(&ast.CallExpr{
+			Fun:  I("Decode" + baseName + "Response"),
+			Args: []ast.Expr{I("resp")},
+		}),
+	)
+
+	g.ClientFile.methodDecls = append(g.ClientFile.methodDecls, Func(baseName,
+		Field("c", Star(I("Client")), ""),
+		[]*ast.Field{
+			Field("ctx", Sel(I("context"), "Context"), ""),
+			Field("request", Sel(I(models), baseName+"Request"), ""),
+		},
+		[]*ast.Field{
+			Field("", Star(Sel(I(models), baseName+"Response")), ""),
+			Field("", I("error"), ""),
+		},
+		body,
+	))
+
+	g.AddDecodeResponseFunc(baseName, operation)
+
+	return nil
+}
+
+// clientPathExpr returns the expression building the request path from the
+// path template and the path parameters of the request.
+func (g *Generator) clientPathExpr(pathName string, params openapi3.Parameters) (ast.Expr, error) {
+	var parts []ast.Expr
+	rest := pathName
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, errors.New("unterminated parameter in path " + pathName)
+		}
+		end += start
+		name := rest[start+1 : end]
+		param := params.GetByInAndName(openapi3.ParameterInPath, name)
+		if param == nil {
+			return nil, errors.New("path parameter " + name + " of " + pathName + " is not declared")
+		}
+		if start > 0 {
+			parts = append(parts, Str(rest[:start]))
+		}
+		g.AddClientImport("net/url")
+		value := ast.Expr(Sel(Sel(I("request"), "Path"), FormatGoLikeIdentifier(name)))
+		if g.Opts.RequiredFieldsArePointers {
+			value = Star(value)
+		}
+		parts = append(parts, &ast.CallExpr{
+			Fun:  Sel(I("url"), "PathEscape"),
+			Args: []ast.Expr{g.clientFormatExpr(param.Schema, value, true)},
+		})
+		rest = rest[end+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, Str(rest))
+	}
+
+	expr := parts[0]
+	for _, part := range parts[1:] {
+		expr = &ast.BinaryExpr{X: expr, Op: token.ADD, Y: part}
+	}
+
+	return expr, nil
+}
+
+// clientBodyStmts returns the statements setting the body of the request to
+// body, encoded in contentType.
+func (g *Generator) clientBodyStmts(baseName string, contentType string, body ast.Expr,
+	content *openapi3.MediaType,
+) ([]ast.Stmt, error) {
+	if contentType == applicationFormCT || contentType == multipartFormCT {
+		return g.clientFormStmts(baseName, contentType, body, content)
+	}
+	if isBinaryContent(contentType, content) {
+		return g.clientBinaryStmts(contentType, body), nil
+	}
+
+	result := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{Sel(I("cr"), "body")},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{body},
+	}}
+	if contentType != applicationJSONCT {
+		result = append(result, &ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I("cr"), "contentType")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{Str(contentType)},
+		})
+	}
+
+	return result, nil
+}
+
+// clientOptional returns the statements produced for the value of field,
+// guarded by a nil check when the field is a pointer.
+func (g *Generator) clientOptional(field ast.Expr, required bool, stmts func(value ast.Expr) []ast.Stmt) []ast.Stmt {
+	if required && !g.Opts.RequiredFieldsArePointers {
+		return stmts(field)
+	}
+
+	return []ast.Stmt{&ast.IfStmt{
+		Cond: Ne(field, I("nil")),
+		Body: &ast.BlockStmt{List: stmts(Star(field))},
+	}}
+}
+
+// clientQueryParam returns the statements adding a query parameter to cr.query
+// in the style its parser expects.
+func (g *Generator) clientQueryParam(query ast.Expr, param *openapi3.Parameter) ([]ast.Stmt, error) {
+	fieldName := FormatGoLikeIdentifier(param.Name)
+	field := Sel(query, fieldName)
+	style, explode := paramSerialization(param)
+	queryCall := func(method string, key ast.Expr, value ast.Expr) ast.Stmt {
+		return &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(Sel(I("cr"), "query"), method),
+			Args: []ast.Expr{key, value},
+		}}
+	}
+
+	switch {
+	case param.Schema.Value.Type.Permits(openapi3.TypeArray):
+		separator, ok := queryArraySeparators[style]
+		if !ok {
+			return nil, errors.New("unsupported style " + style + " for array query parameter " + param.Name)
+		}
+		items := param.Schema.Value.Items
+		return g.clientOptional(field, param.Required, func(value ast.Expr) []ast.Stmt {
+			return g.clientArrayStmts(Sel(I("cr"), "query"), param.Name, items, value, explode, separator, true)
+		}), nil
+	case param.Schema.Value.Type.Permits(openapi3.TypeObject):
+		var queryKey func(propName string) string
+		switch {
+		case style == openapi3.SerializationDeepObject:
+			// filter[status]=open&filter[owner]=me
+			queryKey = func(propName string) string { return param.Name + "[" + propName + "]" }
+		case style == openapi3.SerializationForm && explode:
+			// status=open&owner=me
+			queryKey = func(propName string) string { return propName }
+		default:
+			return nil, errors.New("unsupported style " + style + " for object query parameter " + param.Name)
+		}
+
+		schema := param.Schema.Value
+		var stmts []ast.Stmt
+		for _, propName := range sortedKeys(schema.Properties) {
+			propField := Sel(field, FormatGoLikeIdentifier(propName))
+			propSchema := schema.Properties[propName]
+			stmts = append(stmts, g.clientOptional(propField, slices.Contains(schema.Required, propName),
+				func(value ast.Expr) []ast.Stmt {
+					// object models share the JSON body mapping, which keeps dates and
+					// uuids as strings
+					return []ast.Stmt{queryCall("Set", Str(queryKey(propName)), g.clientFormatExpr(propSchema, value, false))}
+				},
+			)...)
+		}
+		if param.Required && !g.Opts.RequiredFieldsArePointers {
+			return stmts, nil
+		}
+		return []ast.Stmt{&ast.IfStmt{
+			Cond: Ne(field, I("nil")),
+			Body: &ast.BlockStmt{List: stmts},
+		}}, nil
+	}
+
+	return g.clientOptional(field, param.Required, func(value ast.Expr) []ast.Stmt {
+		return []ast.Stmt{queryCall("Set", Str(param.Name), g.clientFormatExpr(param.Schema, value, true))}
+	}), nil
+}
+
+// clientArrayStmts returns the statements adding the items of the array value
+// under name to the url.Values target: one pair per item when exploded, else
+// a single pair joined with separator.
+func (g *Generator) clientArrayStmts(target ast.Expr, name string, items *openapi3.SchemaRef, value ast.Expr,
+	explode bool, separator string, paramTyped bool,
+) []ast.Stmt {
+	valuesCall := func(method string, value ast.Expr) ast.Stmt {
+		return &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(target, method),
+			Args: []ast.Expr{Str(name), value},
+		}}
+	}
+	if explode {
+		// tag=a&tag=b
+		return []ast.Stmt{&ast.RangeStmt{
+			Key:   I("_"),
+			Value: I("item"),
+			Tok:   token.DEFINE,
+			X:     value,
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				valuesCall("Add", g.clientFormatExpr(items, I("item"), paramTyped)),
+			}},
+		}}
+	}
+	// ids=1,2,3 / ids=1%202%203 / ids=1|2|3
+	g.AddClientImport("strings")
+	valuesName := GoIdentLowercase(FormatGoLikeIdentifier(name)) + "Values"
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I(valuesName)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: I("make"),
+				Args: []ast.Expr{
+					&ast.ArrayType{Elt: I("string")},
+					intLit("0"),
+					&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{value}},
+				},
+			}},
+		},
+		&ast.RangeStmt{
+			Key:   I("_"),
+			Value: I("item"),
+			Tok:   token.DEFINE,
+			X:     value,
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{I(valuesName)},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  I("append"),
+					Args: []ast.Expr{I(valuesName), g.clientFormatExpr(items, I("item"), paramTyped)},
+				}},
+			}}},
+		},
+		valuesCall("Set", &ast.CallExpr{
+			Fun:  Sel(I("strings"), "Join"),
+			Args: []ast.Expr{I(valuesName), Str(separator)},
+		}),
+	}
+}
+
+// clientFormatExpr returns the expression formatting value, of the Go type of
+// schema, into the string its parser reads. paramTyped tells whether a
+// `format: date` or `format: uuid` string is held in a time.Time or a uuid.UUID,
+// as parameters are.
+func (g *Generator) clientFormatExpr(schema *openapi3.SchemaRef, value ast.Expr, paramTyped bool) ast.Expr {
+	call := func(pkg string, fun string, args ...ast.Expr) ast.Expr {
+		g.AddClientImport(pkg)
+		return &ast.CallExpr{Fun: Sel(I(pkg), fun), Args: args}
+	}
+	convert := func(typeName string, value ast.Expr) ast.Expr {
+		return &ast.CallExpr{Fun: I(typeName), Args: []ast.Expr{value}}
+	}
+	method := func(name string, args ...ast.Expr) ast.Expr {
+		receiver := value
+		if _, ok := value.(*ast.StarExpr); ok {
+			receiver = &ast.ParenExpr{X: value}
+		}
+		return &ast.CallExpr{Fun: Sel(receiver, name), Args: args}
+	}
+
+	switch {
+	case schema.Value.Type.Permits(openapi3.TypeInteger):
+		goType := g.GetIntegerType(schema.Value.Format)
+		switch {
+		case goType == "int":
+			return call("strconv", "Itoa", value)
+		case goType == "int64":
+			return call("strconv", "FormatInt", value, intLit("10"))
+		case goType == "uint64":
+			return call("strconv", "FormatUint", value, intLit("10"))
+		case strings.HasPrefix(goType, "uint"):
+			return call("strconv", "FormatUint", convert("uint64", value), intLit("10"))
+		default:
+			return call("strconv", "FormatInt", convert("int64", value), intLit("10"))
+		}
+	case schema.Value.Type.Permits(openapi3.TypeNumber):
+		return call("strconv", "FormatFloat", value,
+			&ast.BasicLit{Kind: token.CHAR, Value: "'f'"},
+			&ast.UnaryExpr{Op: token.SUB, X: intLit("1")},
+			intLit("64"),
+		)
+	case schema.Value.Type.Permits(openapi3.TypeBoolean):
+		return call("strconv", "FormatBool", value)
+	}
+
+	switch schema.Value.Format {
+	case "date-time":
+		g.AddClientImport("time")
+		return method("Format", Sel(I("time"), "RFC3339Nano"))
+	case "date":
+		if paramTyped {
+			g.AddClientImport("time")
+			return method("Format", Sel(I("time"), "DateOnly"))
+		}
+	case "uuid":
+		if paramTyped {
+			return method("String")
+		}
+	case "decimal":
+		return method("String")
+	}
+
+	return value
+}
+
+// AddDecodeResponseFunc generates Decode<Op>Response, which reads a response
+// into the field of its status code. Bodies pass the layer-1 validator of their
+// schema and, with headers, the struct validator; failures are reported together
+// in a ResponseValidationError.
+func (g *Generator) AddDecodeResponseFunc(baseName string, operation *openapi3.Operation) {
+	models := g.GetCurrentModelsPackage()
+
+	cases := make([]ast.Stmt, 0, len(operation.Responses.Map())+1)
+	for _, code := range sortedKeys(operation.Responses.Map()) {
+		response := operation.Responses.Value(code)
+		responseField := Sel(I("response"), "Response"+code)
+		caseBody := []ast.Stmt{&ast.AssignStmt{
+			Lhs: []ast.Expr{responseField},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{Amp(&ast.CompositeLit{Type: Sel(I(models), baseName+"Response"+code)})},
+		}}
+		errTok := token.DEFINE
+		contentTypes := sortedKeys(response.Value.Content)
+		if len(contentTypes) > 1 {
+			caseBody = append(caseBody, g.decodeNegotiatedResponseStmts(baseName+"Response"+code, responseField, contentTypes, response)...)
+		} else if len(contentTypes) == 1 && response.Value.Content[contentTypes[0]].Schema != nil {
+			schema := response.Value.Content[contentTypes[0]].Schema
+			ref := resolveSchemaRefAgainstResponse(response.Ref, schema.Ref)
+			switch {
+			case isBinaryContent(contentTypes[0], response.Value.Content[contentTypes[0]]):
+				caseBody = append(caseBody, g.readBinaryResponseStmts(Sel(responseField, "Body"))...)
+			case isJSONMediaType(contentTypes[0]):
+				caseBody = append(caseBody, g.decodeResponseBodyStmts(baseName+"Response"+code+"Body",
+					ref, schema, Sel(responseField, "Body"),
+				)...)
+			default:
+				caseBody = append(caseBody, g.readResponseBodyStmts(baseName+"Response"+code+"Body",
+					ref, schema, Sel(responseField, "Body"),
+				)...)
+			}
+			errTok = token.ASSIGN
+		}
+		if len(response.Value.Headers) > 0 {
+			headersField := Sel(responseField, "Headers")
+			args := []ast.Expr{Sel(I("resp"), "Header"), Amp(headersField)}
+			for _, name := range sortedKeys(response.Value.Headers) {
+				args = append(args, Str(name))
+			}
+			caseBody = append(caseBody,
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{I("err")},
+					Tok: errTok,
+					Rhs: []ast.Expr{&ast.CallExpr{Fun: I("decodeResponseHeaders"), Args: args}},
+				},
+				ifNoErr(&ast.CallExpr{
+					Fun:  Sel(I("responseValidator"), "Struct"),
+					Args: []ast.Expr{headersField},
+				}),
+				mergeFieldErrors(Str("header"), I("err")),
+			)
+		}
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: code}},
+			Body: caseBody,
+		})
+	}
+	cases = append(cases, &ast.CaseClause{
+		Body: []ast.Stmt{Ret2(I("nil"), Amp(&ast.CompositeLit{
+			Type: I("UnexpectedStatusError"),
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{Key: I("Operation"), Value: Str(baseName)},
+				&ast.KeyValueExpr{Key: I("StatusCode"), Value: Sel(I("resp"), "StatusCode")},
+			},
+		}))},
+	})
+
+	g.ClientFile.methodDecls = append(g.ClientFile.methodDecls, Func("Decode"+baseName+"Response",
+		nil,
+		[]*ast.Field{
+			Field("resp", Star(Sel(I("http"), "Response")), ""),
+		},
+		[]*ast.Field{
+			Field("", Star(Sel(I(models), baseName+"Response")), ""),
+			Field("", I("error"), ""),
+		},
+		[]ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("response")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CompositeLit{
+					Type: Sel(I(models), baseName+"Response"),
+					Elts: []ast.Expr{&ast.KeyValueExpr{Key: I("StatusCode"), Value: Sel(I("resp"), "StatusCode")}},
+				}},
+			},
+			&ast.DeclStmt{Decl: &ast.GenDecl{
+				Tok:   token.VAR,
+				Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I("errs")}, Type: I("ValidationError")}},
+			}},
+			&ast.SwitchStmt{
+				Tag:  Sel(I("resp"), "StatusCode"),
+				Body: &ast.BlockStmt{List: cases},
+			},
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{Sel(I("errs"), "Errors")}},
+					Op: token.GTR,
+					Y:  intLit("0"),
+				},
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), Amp(&ast.CompositeLit{
+					Type: I("ResponseValidationError"),
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{Key: I("Operation"), Value: Str(baseName)},
+						&ast.KeyValueExpr{Key: I("StatusCode"), Value: Sel(I("resp"), "StatusCode")},
+						&ast.KeyValueExpr{Key: I("Err"), Value: Amp(I("errs"))},
+					},
+				}))}},
+			},
+			Ret2(Amp(I("response")), I("nil")),
+		},
+	))
+}
+
+// decodeResponseBodyStmts returns the statements reading the response body into
+// field, stopping at the first failing step and merging its failures into errs.
+func (g *Generator) decodeResponseBodyStmts(typeName string, ref string, schema *openapi3.SchemaRef, field ast.Expr) []ast.Stmt {
+	stmts := []ast.Stmt{
+		&ast.DeclStmt{Decl: &ast.GenDecl{
+			Tok:   token.VAR,
+			Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I("bodyJSON")}, Type: Sel(I("json"), "RawMessage")}},
+		}},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: Sel(&ast.CallExpr{
+					Fun:  Sel(I("json"), "NewDecoder"),
+					Args: []ast.Expr{Sel(I("resp"), "Body")},
+				}, "Decode"),
+				Args: []ast.Expr{Amp(I("bodyJSON"))},
+			}},
+		},
+	}
+
+	layer1, layer2, importPath := g.responseBodyChecks(typeName, ref, schema, field, I("responseValidator"))
+	if importPath != "" {
+		g.AddClientImport(importPath)
+	}
+	if layer1 != nil {
+		stmts = append(stmts, ifNoErr(&ast.CallExpr{Fun: I("ignoreUnknownFields"), Args: []ast.Expr{layer1}}))
+	}
+	stmts = append(stmts, ifNoErr(&ast.CallExpr{
+		Fun:  Sel(I("json"), "Unmarshal"),
+		Args: []ast.Expr{I("bodyJSON"), Amp(field)},
+	}))
+	if layer2 != nil {
+		stmts = append(stmts, ifNoErr(layer2))
+	}
+
+	return append(stmts, mergeFieldErrors(Str("body"), I("err")))
+}
+
+// decodeNegotiatedResponseStmts returns the statements reading the response
+// body into the field of responseField, the response model modelName, of its
+// content type.
+func (g *Generator) decodeNegotiatedResponseStmts(modelName string, responseField ast.Expr, contentTypes []string,
+	response *openapi3.ResponseRef,
+) []ast.Stmt {
+	g.AddClientImport("mime")
+	clauses := make([]ast.Stmt, 0, len(contentTypes)+1)
+	for _, contentType := range contentTypes {
+		schema := response.Value.Content[contentType].Schema
+		fieldName := responseBodyField(contentType, contentTypes)
+		typeName := modelName + fieldName
+		ref := resolveSchemaRefAgainstResponse(response.Ref, schema.Ref)
+		bodyType := g.clientResponseBodyType(typeName, ref)
+		var stmts []ast.Stmt
+		switch {
+		case isBinaryContent(contentType, response.Value.Content[contentType]):
+			bodyType = Sel(I(g.GetCurrentModelsPackage()), "BinaryBody")
+			stmts = g.readBinaryResponseStmts(I("body"))
+		case isJSONMediaType(contentType):
+			stmts = g.decodeResponseBodyStmts(typeName, ref, schema, I("body"))
+		default:
+			stmts = g.readResponseBodyStmts(typeName, ref, schema, I("body"))
+		}
+		stmts = append([]ast.Stmt{&ast.DeclStmt{Decl: &ast.GenDecl{
+			Tok:   token.VAR,
+			Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I("body")}, Type: bodyType}},
+		}}}, stmts...)
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{Str(contentType)},
+			Body: append(stmts, &ast.AssignStmt{
+				Lhs: []ast.Expr{Sel(responseField, fieldName)},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{Amp(I("body"))},
+			}),
+		})
+	}
+	clauses = append(clauses, &ast.CaseClause{Body: []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+		Fun: Sel(I("errs"), "add"),
+		Args: []ast.Expr{Str("/body"), Str("contentType"), &ast.BinaryExpr{
+			X: Str("unexpected content type "), Op: token.ADD, Y: I("contentType"),
+		}},
+	}}}})
+
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("contentType"), I("_"), I("_")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: Sel(I("mime"), "ParseMediaType"),
+				Args: []ast.Expr{&ast.CallExpr{
+					Fun:  Sel(Sel(I("resp"), "Header"), "Get"),
+					Args: []ast.Expr{Str("Content-Type")},
+				}},
+			}},
+		},
+		&ast.SwitchStmt{Tag: I("contentType"), Body: &ast.BlockStmt{List: clauses}},
+	}
+}
+
+// readResponseBodyStmts returns the statements reading the response body, of a
+// media type written as is, into field, merging its failures into errs.
+func (g *Generator) readResponseBodyStmts(typeName string, ref string, schema *openapi3.SchemaRef, field ast.Expr) []ast.Stmt {
+	g.AddClientImport("io")
+	stmts := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("data"), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("io"), "ReadAll"), Args: []ast.Expr{Sel(I("resp"), "Body")}}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{field},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: g.clientResponseBodyType(typeName, ref), Args: []ast.Expr{I("data")}}},
+		},
+	}
+	_, layer2, _ := g.responseBodyChecks(typeName, ref, schema, field, I("responseValidator"))
+	if layer2 != nil {
+		stmts = append(stmts, ifNoErr(layer2))
+	}
+
+	return append(stmts, mergeFieldErrors(Str("body"), I("err")))
+}
+
+// clientResponseBodyType returns the type of the body of a response in the
+// client file, the model typeName or the one of the schema ref.
+func (g *Generator) clientResponseBodyType(typeName string, ref string) ast.Expr {
+	if ref == "" {
+		return Sel(I(g.GetCurrentModelsPackage()), typeName)
+	}
+	typeName, importPath := g.ParseRefTypeName(ref)
+	if importPath != "" {
+		g.AddClientImport(importPath)
+	}
+	if refIsExternal(ref) {
+		return I(typeName)
+	}
+
+	return Sel(I(g.GetCurrentModelsPackage()), typeName)
+}
+
+// ifNoErr runs call, assigning its result to err, unless err is already set.
+func ifNoErr(call ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: Eq(I("err"), I("nil")),
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{call},
+		}}},
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+// mergeAllOf flattens the allOf members of schema, together with the properties
+// declared next to allOf, into a single object schema. Required lists are
+// combined; a property declared by several members must map to the same Go type.
+// Local references of members loaded from refFile are rebased onto that file.
+func (g *Generator) mergeAllOf(modelName string, schema *openapi3.Schema, refFile string) (*openapi3.Schema, error) {
+	const op = "generator.mergeAllOf"
+	merged := &openapi3.Schema{
+		Type:       &openapi3.Types{openapi3.TypeObject},
+		Properties: make(openapi3.Schemas),
+		Nullable:   schema.Nullable,
+	}
+
+	own := *schema
+	own.AllOf = nil
+	members := append(slices.Clone(schema.AllOf), &openapi3.SchemaRef{Value: &own})
+
+	required := make(map[string]bool)
+	for _, member := range members {
+		value := member.Value
+		if value == nil {
+			continue
+		}
+		memberFile := refFile
+		if member.Ref != "" && refIsExternal(member.Ref) {
+			memberFile = parseFilenameFromRef(member.Ref)
+		}
+		if len(value.AllOf) > 0 {
+			var err error
+			value, err = g.mergeAllOf(modelName, value, memberFile)
+			if err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+		}
+		if value.Type != nil && !value.Type.Is(openapi3.TypeObject) {
+			return nil, errors.Errorf("allOf member %s of %s must be an object", member.Ref, modelName)
+		}
+
+		for _, propName := range sortedKeys(value.Properties) {
+			prop := rebaseSchemaRef(value.Properties[propName], memberFile)
+			existing, ok := merged.Properties[propName]
+			if !ok {
+				merged.Properties[propName] = prop
+				continue
+			}
+			existingType, err := g.GetFieldTypeFromSchema(modelName, propName, existing)
+			if err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+			propType, err := g.GetFieldTypeFromSchema(modelName, propName, prop)
+			if err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+			if existingType != propType {
+				return nil, errors.Errorf("allOf members of %s declare property %s as both %s and %s",
+					modelName, propName, existingType, propType)
+			}
+		}
+		for _, propName := range value.Required {
+			required[propName] = true
+		}
+	}
+	merged.Required = sortedKeys(required)
+
+	return merged, nil
+}
+
+// rebaseSchemaRef makes a local reference found in refFile usable from the
+// document being generated.
+func rebaseSchemaRef(schema *openapi3.SchemaRef, refFile string) *openapi3.SchemaRef {
+	if refFile == "" || schema.Ref == "" || refIsExternal(schema.Ref) {
+		return schema
+	}
+
+	return &openapi3.SchemaRef{Ref: refFile + schema.Ref, Value: schema.Value}
+}
@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"hash/fnv"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const patternValidatorsSrc = `package _
+
+func registerPatternValidators(v *validator.Validate) {
+	for tag, re := range patternValidators {
+		err := v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			if fl.Field().Kind() != reflect.String {
+				return true
+			}
+			return re.MatchString(fl.Field().String())
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+`
+
+// PatternTag returns the validator tag checking a string against pattern. The
+// name only depends on the pattern, so models of different packages sharing a
+// pattern share the tag and any generated handler can register it.
+func PatternTag(pattern string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(pattern))
+
+	return fmt.Sprintf("pattern_%08x", h.Sum32())
+}
+
+// CollectCustomValidators records the custom validator tags reachable from the
+// document, external references included, so the handlers file registers every
+// tag its models may carry. Patterns Go's RE2 engine cannot compile abort the
+// generation. It also marks the schemas rejecting unknown properties.
+func (g *Generator) CollectCustomValidators() error {
+	const op = "generator.CollectCustomValidators"
+	c := customValidatorCollector{
+		file:            g.HandlersFile,
+		visited:         make(map[*openapi3.Schema]bool),
+		strictByDefault: g.Opts.StrictJSON,
+	}
+
+	if g.yaml.Components != nil {
+		for _, name := range sortedKeys(g.yaml.Components.Schemas) {
+			if err := c.schema("#/components/schemas/"+name, g.yaml.Components.Schemas[name], c.strictByDefault); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+		for _, name := range sortedKeys(g.yaml.Components.Parameters) {
+			if err := c.parameter("#/components/parameters/"+name, g.yaml.Components.Parameters[name]); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+	}
+	if g.yaml.Paths == nil {
+		return nil
+	}
+	for _, pathName := range g.yaml.Paths.InMatchingOrder() {
+		pathItem := g.yaml.Paths.Value(pathName)
+		for _, param := range pathItem.Parameters {
+			if err := c.parameter(pathName, param); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+		for method, operation := range pathItem.Operations() {
+			where := method + " " + pathName
+			for _, param := range operation.Parameters {
+				if err := c.parameter(where, param); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				if err := c.content(where+" request body", operation.RequestBody.Value.Content); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			if operation.Responses == nil {
+				continue
+			}
+			for code, response := range operation.Responses.Map() {
+				if response.Value == nil {
+					continue
+				}
+				if err := c.content(where+" response "+code, response.Value.Content); err != nil {
+					return errors.Wrap(err, op)
+				}
+				for name, header := range response.Value.Headers {
+					if header.Value == nil {
+						continue
+					}
+					if err := c.schema(where+" response "+code+" header "+name, header.Value.Schema, c.strictByDefault); err != nil {
+						return errors.Wrap(err, op)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// typedStringFormats are the string formats read into another type than
+// string, whose pattern is dropped: the type checks the format.
+var typedStringFormats = map[string]bool{
+	"date-time": true,
+	"decimal":   true,
+}
+
+// warnTypedPattern warns that the pattern of a schema of a typed format is not
+// checked.
+func warnTypedPattern(where string, schema *openapi3.Schema) {
+	slog.Warn("pattern validator is not supported", slog.String("pattern", schema.Pattern),
+		slog.String("schema", where), slog.String("format", schema.Format))
+}
+
+type customValidatorCollector struct {
+	file            *HandlersFile
+	visited         map[*openapi3.Schema]bool
+	strictByDefault bool
+}
+
+func (c *customValidatorCollector) parameter(where string, param *openapi3.ParameterRef) error {
+	if param == nil || param.Value == nil {
+		return nil
+	}
+	where += " parameter " + param.Value.Name
+	// an inline date or uuid parameter, or array of them, is parsed into
+	// time.Time or uuid.UUID
+	schema := param.Value.Schema
+	if schema != nil && schema.Ref == "" && schema.Value.Type.Permits(openapi3.TypeArray) {
+		schema = schema.Value.Items
+	}
+	if schema != nil && schema.Ref == "" && isParamTypedFormat(schema.Value.Format) && schema.Value.Pattern != "" {
+		warnTypedPattern(where, schema.Value)
+	}
+
+	return c.schema(where, param.Value.Schema, c.strictByDefault)
+}
+
+func (c *customValidatorCollector) content(where string, content openapi3.Content) error {
+	for _, contentType := range sortedKeys(content) {
+		if err := c.schema(where+" "+contentType, content[contentType].Schema, c.strictByDefault); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *customValidatorCollector) schema(where string, schema *openapi3.SchemaRef, inheritedStrict bool) error {
+	if schema == nil || schema.Value == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		where = schema.Ref
+		inheritedStrict = c.strictByDefault
+	}
+	strict, err := schemaStrictness(where, schema.Value, inheritedStrict)
+	if err != nil {
+		return err
+	}
+	// a schema reached again from a strict parent is walked again to mark its inline children
+	if c.visited[schema.Value] && (!strict || c.file.strictSchemas[schema.Value]) {
+		return nil
+	}
+	c.visited[schema.Value] = true
+	if strict {
+		c.file.strictSchemas[schema.Value] = true
+	}
+
+	if typedStringFormats[schema.Value.Format] && schema.Value.Pattern != "" {
+		warnTypedPattern(where, schema.Value)
+	} else if pattern := schema.Value.Pattern; pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.Wrapf(err, "pattern %q of %s is not supported by Go's RE2 engine", pattern, where)
+		}
+		tag := PatternTag(pattern)
+		if other, ok := c.file.patterns[tag]; ok && other != pattern {
+			return errors.Errorf("patterns %q and %q share the validator tag %s", other, pattern, tag)
+		}
+		c.file.patterns[tag] = pattern
+	}
+	if schema.Value.MultipleOf != nil {
+		c.file.hasMultipleOf = true
+	}
+
+	for _, name := range sortedKeys(schema.Value.Properties) {
+		if err := c.schema(where+"/properties/"+name, schema.Value.Properties[name], strict); err != nil {
+			return err
+		}
+	}
+	if err := c.schema(where+"/items", schema.Value.Items, strict); err != nil {
+		return err
+	}
+	if err := c.schema(where+"/additionalProperties", schema.Value.AdditionalProperties.Schema, strict); err != nil {
+		return err
+	}
+	for _, composed := range []openapi3.SchemaRefs{schema.Value.AllOf, schema.Value.OneOf, schema.Value.AnyOf} {
+		for _, member := range composed {
+			if err := c.schema(where, member, strict); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// patternLit prefers a raw string literal so the generated pattern reads like the spec.
+func patternLit(pattern string) *ast.BasicLit {
+	if strings.ContainsAny(pattern, "`\r\n") {
+		return Str(pattern)
+	}
+
+	return &ast.BasicLit{Kind: token.STRING, Value: "`" + pattern + "`"}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// AddPatternValidators emits the compiled patterns of the handlers file and the
+// helper registering them on the validator of the Handler.
+func (g *Generator) AddPatternValidators() {
+	if len(g.HandlersFile.patterns) == 0 {
+		return
+	}
+	g.AddHandlersImport("reflect")
+	g.AddHandlersImport("regexp")
+
+	elts := make([]ast.Expr, 0, len(g.HandlersFile.patterns))
+	for _, tag := range sortedKeys(g.HandlersFile.patterns) {
+		elts = append(elts, &ast.KeyValueExpr{
+			Key: Str(tag),
+			Value: &ast.CallExpr{
+				Fun:  Sel(I("regexp"), "MustCompile"),
+				Args: []ast.Expr{patternLit(g.HandlersFile.patterns[tag])},
+			},
+		})
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{I("patternValidators")},
+				Values: []ast.Expr{&ast.CompositeLit{
+					Type: &ast.MapType{
+						Key:   I("string"),
+						Value: Star(Sel(I("regexp"), "Regexp")),
+					},
+					Elts: elts,
+				}},
+			},
+		},
+	})
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", patternValidatorsSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+const multipleOfValidatorSrc = `package _
+
+func registerMultipleOfValidator(v *validator.Validate) {
+	err := v.RegisterValidation("multipleof", validateMultipleOf)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func validateMultipleOf(fl validator.FieldLevel) bool {
+	divisor, err := decimal.NewFromString(fl.Param())
+	if err != nil || !divisor.IsPositive() {
+		return false
+	}
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimal.NewFromInt(field.Int()).Mod(divisor).IsZero()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return decimal.NewFromUint64(field.Uint()).Mod(divisor).IsZero()
+	case reflect.Float32, reflect.Float64:
+		// binary floats rarely divide exactly, so accept quotients within rounding error
+		quotient := field.Float() / divisor.InexactFloat64()
+		return math.Abs(quotient-math.Round(quotient)) <= 1e-9*math.Max(1, math.Abs(quotient))
+	}
+	value, ok := field.Interface().(decimal.Decimal)
+	if !ok {
+		return false
+	}
+	return value.Mod(divisor).IsZero()
+}
+`
+
+// AddMultipleOfValidator emits the multipleof validator, which accepts integers,
+// floats and decimal.Decimal values.
+func (g *Generator) AddMultipleOfValidator() {
+	if !g.HandlersFile.hasMultipleOf {
+		return
+	}
+	g.AddHandlersImport("math")
+	g.AddHandlersImport("reflect")
+	g.AddHandlersImport("github.com/shopspring/decimal")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", multipleOfValidatorSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
@@ -21,6 +21,10 @@ func (h *Handler) SetErrorHandler(eh ErrorHandler) { h.errorHandler = eh }
 var DefaultErrorHandler ErrorHandler = func(w http.ResponseWriter, r *http.Request, status int, msg string) {
 	http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(msg)), status)
 }
+
+// ErrNotImplemented is returned by a handler for an operation it does not
+// implement yet; it is answered with 501.
+var ErrNotImplemented = errors.New("not implemented")
 `
 
 func parseStandardErrorHandlerDecls() []ast.Decl {
@@ -48,6 +52,7 @@ func (g *Generator) AddStandardErrorDecls() {
 	g.AddHandlersImport("fmt")
 	g.AddHandlersImport("net/http")
 	g.AddHandlersImport("strconv")
+	g.AddHandlersImport("github.com/go-faster/errors")
 	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, parseStandardErrorHandlerDecls()...)
 }
 
@@ -0,0 +1,538 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const applicationFormCT = "application/x-www-form-urlencoded"
+
+const formSrc = `package _
+
+// WithFormLimit sets the maximum size of an application/x-www-form-urlencoded
+// body, 0 for no limit.
+func WithFormLimit(maxSize int64) Option {
+	return func(h *Handler) {
+		h.formMaxSize = maxSize
+	}
+}
+
+// limitFormBody caps the application/x-www-form-urlencoded body of r at the
+// maximum size of h: reading past it fails with an *http.MaxBytesError.
+func (h *Handler) limitFormBody(w http.ResponseWriter, r *http.Request) {
+	if h.formMaxSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.formMaxSize)
+	}
+}
+`
+
+// AddFormDecls adds the option and helper limiting
+// application/x-www-form-urlencoded bodies, when an operation accepts one.
+func (g *Generator) AddFormDecls() {
+	if len(g.HandlersFile.urlencoded) == 0 {
+		return
+	}
+	g.AddHandlersImport("net/http")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", formSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// addFormDependencies adds the maximum size of
+// application/x-www-form-urlencoded bodies, 10 MB by default like net/http, to
+// the Handler.
+func (g *Generator) addFormDependencies() {
+	if len(g.HandlersFile.urlencoded) == 0 {
+		return
+	}
+	g.HandlersFile.handlerDeclQAFieldList.List = append(g.HandlersFile.handlerDeclQAFieldList.List,
+		Field("formMaxSize", I("int64"), ""),
+	)
+	g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts = append(
+		g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts,
+		&ast.KeyValueExpr{
+			Key:   I("formMaxSize"),
+			Value: &ast.BinaryExpr{X: intLit("10"), Op: token.SHL, Y: intLit("20")},
+		},
+	)
+}
+
+// formProperties checks that the schema of a form body can be read from form
+// fields: an object whose properties are inline scalars or arrays of scalars,
+// which may be files in a multipart body, and returns the names of the
+// properties in order.
+func formProperties(where string, content *openapi3.MediaType, multipart bool) ([]string, error) {
+	schema := content.Schema.Value
+	if !schema.Type.Permits(openapi3.TypeObject) || len(schema.Properties) == 0 {
+		return nil, errors.Errorf("form body of %s must be an object with properties", where)
+	}
+	propNames := sortedKeys(schema.Properties)
+	for _, propName := range propNames {
+		propSchema := schema.Properties[propName]
+		if propSchema.Ref != "" {
+			return nil, errors.Errorf("property %s of the form body of %s must declare its schema inline", propName, where)
+		}
+		if propSchema.Value.Type.Permits(openapi3.TypeObject) {
+			return nil, errors.Errorf("property %s of the form body of %s must be a scalar or an array", propName, where)
+		}
+		fileSchema := propSchema.Value
+		if propSchema.Value.Type.Permits(openapi3.TypeArray) {
+			items := propSchema.Value.Items
+			if items == nil || items.Ref != "" || items.Value.Type.Permits(openapi3.TypeArray) ||
+				items.Value.Type.Permits(openapi3.TypeObject) {
+				return nil, errors.Errorf("property %s of the form body of %s must declare scalar items inline", propName, where)
+			}
+			fileSchema = items.Value
+		}
+		if isBinarySchema(fileSchema) {
+			if !multipart {
+				return nil, errors.Errorf("file property %s of the form body of %s requires %s", propName, where, multipartFormCT)
+			}
+			continue
+		}
+		if !propSchema.Value.Type.Permits(openapi3.TypeArray) {
+			continue
+		}
+		style, _ := encodingSerialization(content.Encoding[propName])
+		if _, ok := queryArraySeparators[style]; !ok {
+			return nil, errors.Errorf("unsupported style %s for array property %s of the form body of %s", style, propName, where)
+		}
+	}
+
+	return propNames, nil
+}
+
+// AddParseFormRequestBodyMethod generates parse<Op>RequestBody for a form body,
+// urlencoded or multipart: the fields are read into the body model like query
+// params, in the style of their encoding, and every failure is collected like
+// for a JSON body. An empty value is the null of a form: it fails a required
+// field that is not nullable. The file parts of a multipart body are opened
+// into MultipartFile values once their content type is checked.
+func (g *Generator) AddParseFormRequestBodyMethod(baseName string, contentType string, content *openapi3.MediaType,
+	required bool,
+) error {
+	const op = "generator.AddParseFormRequestBodyMethod"
+	multipart := contentType == multipartFormCT
+	propNames, err := formProperties(baseName, content, multipart)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	typeName, bodyType := g.requestBodyType(baseName, content)
+	schema := content.Schema.Value
+
+	var bodyList []ast.Stmt
+	if multipart {
+		files := 0
+		for _, propName := range propNames {
+			if isFormFile(schema.Properties[propName].Value) {
+				files++
+			}
+		}
+		bodyList = g.multipartPreamble(files < len(propNames), files > 0, required)
+	} else {
+		bodyList = g.urlencodedPreamble(required)
+	}
+	bodyList = append(bodyList,
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{I("body")},
+						Type:  bodyType,
+					},
+				},
+			},
+		},
+		g.errsDecl(),
+	)
+
+	// the slice types of the properties and MultipartFile are declared next to
+	// the body model
+	modelType := func(name string) ast.Expr {
+		if sel, ok := bodyType.(*ast.SelectorExpr); ok {
+			return Sel(sel.X, name)
+		}
+		if pkg, _, ok := strings.Cut(typeName, "."); ok {
+			return I(pkg + "." + name)
+		}
+		return I(name)
+	}
+	for _, propName := range propNames {
+		propSchema := schema.Properties[propName]
+		propRequired := slices.Contains(schema.Required, propName)
+		var sliceType ast.Expr = I(typeName + FormatGoLikeIdentifier(propName))
+		if sel, ok := bodyType.(*ast.SelectorExpr); ok {
+			sliceType = Sel(sel.X, typeName+FormatGoLikeIdentifier(propName))
+		}
+		var stmts []ast.Stmt
+		switch {
+		case isFormFile(propSchema.Value):
+			stmts = g.parseMultipartFileField(sliceType, modelType("MultipartFile"), propName, propSchema,
+				content.Encoding[propName], propRequired)
+		case propSchema.Value.Type.Permits(openapi3.TypeArray):
+			stmts = g.parseFormArrayField(sliceType, propName, propSchema, content.Encoding[propName], propRequired)
+		default:
+			stmts, err = g.parseFormField(propName, propSchema, propRequired)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+		bodyList = append(bodyList, collectParamErrors(propName, stmts)...)
+	}
+	bodyList = append(bodyList, collectedParamsEnd("body")...)
+
+	params := []*ast.Field{
+		Field("r", Star(Sel(I("http"), "Request")), ""),
+	}
+	if multipart {
+		// the file parts are opened into the files of the request
+		params = append(params, Field("opened", Star(I("multipartFiles")), ""))
+	}
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func(
+		"parse"+baseName+"RequestBody",
+		Field("h", Star(I("Handler")), ""),
+		params,
+		[]*ast.Field{
+			Field("", Star(bodyType), ""),
+			Field("", I("error"), ""),
+		},
+		bodyList,
+	))
+
+	return nil
+}
+
+// isFormFile reports whether the form property is a file or an array of files.
+func isFormFile(schema *openapi3.Schema) bool {
+	if schema.Type.Permits(openapi3.TypeArray) {
+		return schema.Items != nil && isBinarySchema(schema.Items.Value)
+	}
+
+	return isBinarySchema(schema)
+}
+
+// urlencodedPreamble returns the statements reading the urlencoded body into
+// the form values.
+func (g *Generator) urlencodedPreamble(required bool) []ast.Stmt {
+	g.AddHandlersImport("io")
+	g.AddHandlersImport("net/url")
+
+	bodyList := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("data"), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("io"), "ReadAll"), Args: []ast.Expr{Sel(I("r"), "Body")}}},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("form"), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("url"), "ParseQuery"),
+				Args: []ast.Expr{&ast.CallExpr{Fun: I("string"), Args: []ast.Expr{I("data")}}},
+			}},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
+		},
+	}
+	if !required {
+		bodyList = append(bodyList, &ast.IfStmt{
+			Cond: Eq(&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I("form")}}, intLit("0")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("nil"))}},
+		})
+	}
+
+	return bodyList
+}
+
+// parseFormField returns the statements reading the scalar form field propName
+// into body.
+func (g *Generator) parseFormField(propName string, schema *openapi3.SchemaRef, required bool) ([]ast.Stmt, error) {
+	fieldName := FormatGoLikeIdentifier(propName)
+	varName := "form" + fieldName
+
+	var assign []ast.Stmt
+	if schema.Value.Type.Permits(openapi3.TypeString) && isParamTypedFormat(schema.Value.Format) {
+		// body models keep dates and uuids as strings
+		assign = []ast.Stmt{g.assignRawField("body", varName, fieldName, required)}
+	} else {
+		var err error
+		assign, err = g.assignParamField("body", varName, fieldName, schema, required)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{I(varName)},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("form"), "Get"), Args: []ast.Expr{Str(propName)}}},
+	}}
+	if required {
+		result = append(result, &ast.IfStmt{
+			Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.CallExpr{Fun: Sel(I("form"), "Has"), Args: []ast.Expr{Str(propName)}}},
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"),
+				requiredParamError("field "+propName+" is required"),
+			)}},
+		})
+		if !schema.Value.Nullable {
+			result = append(result, &ast.IfStmt{
+				Cond: Eq(I(varName), Str("")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"),
+					fieldError("null", "field "+propName+" cannot be null"),
+				)}},
+			})
+
+			return append(result, assign...), nil
+		}
+	}
+
+	return append(result, &ast.IfStmt{
+		Cond: Ne(I(varName), Str("")),
+		Body: &ast.BlockStmt{List: assign},
+	}), nil
+}
+
+// parseFormArrayField returns the statements reading the array form field
+// propName, repeated or delimited as its encoding says, into body. The items
+// are collected into the slice type of the body model.
+func (g *Generator) parseFormArrayField(sliceType ast.Expr, propName string, schema *openapi3.SchemaRef,
+	encoding *openapi3.Encoding, required bool,
+) []ast.Stmt {
+	fieldName := FormatGoLikeIdentifier(propName)
+	varName := "form" + fieldName
+	valuesName := varName + "Values"
+	itemsName := varName + "Items"
+
+	var result []ast.Stmt
+	style, explode := encodingSerialization(encoding)
+	if explode {
+		// tag=a&tag=b
+		result = append(result, &ast.AssignStmt{
+			Lhs: []ast.Expr{I(valuesName)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.IndexExpr{X: I("form"), Index: Str(propName)}},
+		})
+	} else {
+		// ids=1,2,3 / ids=1%202%203 / ids=1|2|3
+		g.AddHandlersImport("strings")
+		result = append(result,
+			&ast.DeclStmt{
+				Decl: &ast.GenDecl{
+					Tok: token.VAR,
+					Specs: []ast.Spec{
+						&ast.ValueSpec{
+							Names: []*ast.Ident{I(valuesName)},
+							Type:  &ast.ArrayType{Elt: I("string")},
+						},
+					},
+				},
+			},
+			&ast.IfStmt{
+				Init: &ast.AssignStmt{
+					Lhs: []ast.Expr{I(varName)},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("form"), "Get"), Args: []ast.Expr{Str(propName)}}},
+				},
+				Cond: Ne(I(varName), Str("")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I(valuesName)},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{&ast.CallExpr{
+							Fun:  Sel(I("strings"), "Split"),
+							Args: []ast.Expr{I(varName), Str(queryArraySeparators[style])},
+						}},
+					},
+				}},
+			},
+		)
+	}
+
+	items := schema.Value.Items
+	var itemExpr ast.Expr = I("item")
+	var parseStmts []ast.Stmt
+	if !isParamTypedFormat(items.Value.Format) {
+		parseCall, errMsg, castType := g.scalarParseCall(items, "item")
+		if parseCall != nil {
+			g.AddHandlersImport("github.com/go-faster/errors")
+			itemExpr = I("parsedItem")
+			if castType != "" {
+				itemExpr = &ast.CallExpr{Fun: I(castType), Args: []ast.Expr{itemExpr}}
+			}
+			parseStmts = []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{I("parsedItem"), I("err")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{parseCall},
+				},
+				&ast.IfStmt{
+					Cond: Ne(I("err"), I("nil")),
+					Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(
+						I("nil"),
+						&ast.CallExpr{
+							Fun:  Sel(I("errors"), "Wrap"),
+							Args: []ast.Expr{I("err"), Str(fieldName + " item " + errMsg)},
+						},
+					)}},
+				},
+			}
+		}
+	}
+	var value ast.Expr = I(itemsName)
+	if !required || g.HandlersFile.requiredFieldsArePointers {
+		value = Amp(value)
+	}
+	assign := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I(itemsName)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: I("make"),
+				Args: []ast.Expr{
+					sliceType,
+					intLit("0"),
+					&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(valuesName)}},
+				},
+			}},
+		},
+		&ast.RangeStmt{
+			Key:   I("_"),
+			Value: I("item"),
+			Tok:   token.DEFINE,
+			X:     I(valuesName),
+			Body: &ast.BlockStmt{List: append(parseStmts, &ast.AssignStmt{
+				Lhs: []ast.Expr{I(itemsName)},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  I("append"),
+					Args: []ast.Expr{I(itemsName), itemExpr},
+				}},
+			})},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I("body"), fieldName)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{value},
+		},
+	}
+
+	if required {
+		result = append(result, &ast.IfStmt{
+			Cond: Eq(&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(valuesName)}}, intLit("0")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"),
+				requiredParamError("field "+propName+" is required"),
+			)}},
+		})
+
+		return append(result, assign...)
+	}
+
+	return append(result, &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(valuesName)}},
+			Op: token.GTR,
+			Y:  intLit("0"),
+		},
+		Body: &ast.BlockStmt{List: assign},
+	})
+}
+
+// clientFormStmts returns the statements encoding the form body the way
+// AddParseFormRequestBodyMethod reads it: into cr.form when urlencoded, or
+// streamed into cr.raw with its boundary in cr.contentType when multipart.
+func (g *Generator) clientFormStmts(baseName string, contentType string, body ast.Expr,
+	content *openapi3.MediaType,
+) ([]ast.Stmt, error) {
+	multipart := contentType == multipartFormCT
+	propNames, err := formProperties(baseName, content, multipart)
+	if err != nil {
+		return nil, err
+	}
+	g.AddClientImport("net/url")
+
+	form := I("form")
+	result := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{form},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.CompositeLit{Type: Sel(I("url"), "Values")}},
+	}}
+	var files []ast.Stmt
+	schema := content.Schema.Value
+	for _, propName := range propNames {
+		propSchema := schema.Properties[propName]
+		field := Sel(body, FormatGoLikeIdentifier(propName))
+		required := slices.Contains(schema.Required, propName)
+		if isFormFile(propSchema.Value) {
+			files = append(files, g.clientOptional(field, required, func(value ast.Expr) []ast.Stmt {
+				return clientMultipartFileStmts(propName, propSchema, value)
+			})...)
+			continue
+		}
+		result = append(result, g.clientOptional(field, required,
+			func(value ast.Expr) []ast.Stmt {
+				if propSchema.Value.Type.Permits(openapi3.TypeArray) {
+					style, explode := encodingSerialization(content.Encoding[propName])
+					return g.clientArrayStmts(form, propName, propSchema.Value.Items, value,
+						explode, queryArraySeparators[style], false)
+				}
+				// body models keep dates as strings
+				return []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+					Fun:  Sel(form, "Set"),
+					Args: []ast.Expr{Str(propName), g.clientFormatExpr(propSchema, value, false)},
+				}}}
+			},
+		)...)
+	}
+
+	if !multipart {
+		return append(result, &ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I("cr"), "form")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{form},
+		}), nil
+	}
+
+	// the parts are written while the request is sent
+	g.AddMultipartClientDecls()
+	write := []ast.Stmt{&ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: I("writeMultipartFields"), Args: []ast.Expr{I("mw"), form}}},
+		},
+		Cond: Ne(I("err"), I("nil")),
+		Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+	}}
+	write = append(write, files...)
+
+	return append(result, &ast.AssignStmt{
+		Lhs: []ast.Expr{Sel(I("cr"), "raw"), Sel(I("cr"), "contentType")},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{&ast.CallExpr{
+			Fun: I("multipartBody"),
+			Args: []ast.Expr{&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params:  &ast.FieldList{List: FieldA(Field("mw", Star(Sel(I("multipart"), "Writer")), ""))},
+					Results: &ast.FieldList{List: FieldA(Field("", I("error"), ""))},
+				},
+				Body: &ast.BlockStmt{List: append(write, Ret1(I("nil")))},
+			}},
+		}},
+	}), nil
+}
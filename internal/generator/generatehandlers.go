@@ -21,53 +21,63 @@ func (g *Generator) AddDependencyToHandler(baseName string) {
 	g.AddDependencyToHandlers(baseName)
 }
 
-func (g *Generator) AddRoute(baseName string, method string, pathName string) {
-	g.AddRouteToRouter(baseName, method, pathName)
+func (g *Generator) AddRoute(baseName string, method string, pathName string) error {
+	return g.AddRouteToRouter(baseName, method, pathName)
 }
 
-func (g *Generator) AddContentTypeToHandler(baseName string, rawContentType string) {
+func (g *Generator) AddContentTypeToHandler(baseName string, method string, pathName string, rawContentType string) {
 	if g.GetHandler(baseName) == nil {
-		g.CreateHandler(baseName)
+		g.CreateHandler(baseName, method, pathName)
 	}
 	g.AddContentTypeHandler(baseName, rawContentType)
 }
 
-func (g *Generator) AddHandleOperationMethod(baseName string) {
-	g.AddHandleOperationMethodHandlers(baseName)
+func (g *Generator) AddHandleOperationMethod(baseName string, method string, pathName string, operation *openapi3.Operation) {
+	g.AddHandleOperationMethodHandlers(baseName, method, pathName, operation)
 }
 
 func (g *Generator) AddResponseCodeModels(baseName string, code string, response *openapi3.ResponseRef) error {
 	const op = "generator.AddResponseCodeModels"
-	if len(response.Value.Content) > 1 {
-		return errors.New("multiple response content types are not supported")
+	contentTypes, err := responseContentTypes(baseName+" response "+code, response)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if len(contentTypes) > 1 {
+		g.HandlersFile.hasNegotiatedResponses = true
 	}
 	model := SchemaStruct{
 		Name:   baseName + "Response" + code,
 		Fields: []SchemaField{},
 	}
-	for _, content := range response.Value.Content {
+	for _, contentType := range contentTypes {
+		content := response.Value.Content[contentType]
 		if content.Schema != nil {
+			fieldName := responseBodyField(contentType, contentTypes)
+			typeName := baseName + "Response" + code + fieldName
 			schemaRef := resolveSchemaRefAgainstResponse(response.Ref, content.Schema.Ref)
-			if schemaRef == "" {
-				err := g.ProcessSchema(baseName+"Response"+code+"Body", content.Schema)
+			switch {
+			case isBinaryContent(contentType, content):
+				g.binaryBodyType()
+				typeName = "BinaryBody"
+			case schemaRef == "":
+				err := g.ProcessSchema(typeName, content.Schema)
 				if err != nil {
 					return errors.Wrap(err, op)
 				}
-			}
-			typeName := baseName + "Response" + code + "Body"
-			if schemaRef != "" {
+			default:
 				var importPath string
 				typeName, importPath = g.ParseRefTypeName(schemaRef)
 				if importPath != "" {
 					g.AddSchemasImport(importPath)
 				}
 			}
+			// a negotiated response offers the bodies that are set
 			model.Fields = append(model.Fields, SchemaField{
-				Name:        "Body",
+				Name:        fieldName,
 				Type:        typeName,
 				TagJSON:     []string{},
 				TagValidate: []string{},
-				Required:    true,
+				Required:    len(contentTypes) == 1,
 			})
 		}
 	}
@@ -83,7 +93,7 @@ func (g *Generator) AddResponseCodeModels(baseName string, code string, response
 		})
 	}
 	g.AddSchema(model)
-	err := g.AddCreateResponseModel(baseName, code, response)
+	err = g.AddCreateResponseModel(baseName, code, response)
 	if err != nil {
 		return errors.Wrapf(err, op)
 	}
@@ -160,7 +170,7 @@ func (g *Generator) GetOperationParamsByType(operation *openapi3.Operation, para
 	return result
 }
 
-func (g *Generator) AddParseParamsMethods(baseName string, contentType string, operation *openapi3.Operation) error {
+func (g *Generator) AddParseParamsMethods(baseName string, contentTypes []string, operation *openapi3.Operation) error {
 	const op = "generator.AddParseParamsMethods"
 	var err error
 
@@ -208,47 +218,74 @@ func (g *Generator) AddParseParamsMethods(baseName string, contentType string, o
 			return errors.Wrap(err, op)
 		}
 	}
-	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
-		content, ok := operation.RequestBody.Value.Content[contentType]
-		if ok && content.Schema != nil {
-			if content.Schema.Ref == "" {
-				err = g.ProcessSchema(baseName+"RequestBody", content.Schema)
-				if err != nil {
-					return errors.Wrap(err, op)
-				}
-			}
-			err = g.AddParseRequestBodyMethod(baseName, contentType, operation.RequestBody)
+	for _, contentType := range contentTypes {
+		content := operation.RequestBody.Value.Content[contentType]
+		if content.Schema == nil {
+			continue
+		}
+		bodyName := requestBodyName(baseName, contentType, contentTypes)
+		if content.Schema.Ref == "" && !isBinaryContent(contentType, content) {
+			err = g.ProcessSchema(bodyName+"RequestBody", content.Schema)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
 		}
+		err = g.AddParseRequestBodyMethod(bodyName, contentType, operation.RequestBody)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
 	}
-	g.AddParseRequestMethod(baseName, contentType,
+	g.AddParseRequestMethod(baseName, contentTypes,
 		pathParams, queryParams, headerParams, cookieParams, operation.RequestBody,
 	)
-	g.GenerateRequestModel(baseName, contentType,
+	g.GenerateRequestModel(baseName, contentTypes,
 		pathParams, queryParams, headerParams, cookieParams, operation.RequestBody,
 	)
 
 	return nil
 }
 
-func (g *Generator) ProcessApplicationJSONOperation(pathName string, method string, contentType string,
-	operation *openapi3.Operation,
-) error {
+// ProcessApplicationJSONOperation generates the handler of the operation, which
+// reads the body of every request content type into the same request model.
+func (g *Generator) ProcessApplicationJSONOperation(pathName string, method string, operation *openapi3.Operation) error {
 	const op = "generator.ProcessApplicationJsonOperation"
-	if contentType == "" {
-		contentType = applicationJSONCT
-	}
 	handlerBaseName := FormatGoLikeIdentifier(method) + FormatGoLikeIdentifier(pathName)
 	if operation.OperationID != "" {
 		handlerBaseName = FormatGoLikeIdentifier(operation.OperationID)
 	}
+	contentTypes, err := requestContentTypes(handlerBaseName, operation)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, contentType := range contentTypes {
+		if contentType == multipartFormCT {
+			g.HandlersFile.multipart[handlerBaseName] = true
+		}
+		if contentType == applicationFormCT {
+			g.HandlersFile.urlencoded[handlerBaseName] = true
+		}
+	}
+	if len(contentTypes) > 1 {
+		g.HandlersFile.negotiated[handlerBaseName] = true
+	}
+	if contentType := defaultRequestContentType(contentTypes); contentType != "" {
+		g.HandlersFile.defaultContentTypes[handlerBaseName] = contentType
+	}
 
+	operationID := operation.OperationID
+	if operationID == "" {
+		operationID = handlerBaseName
+	}
 	g.AddInterface(handlerBaseName)
+	g.AddOperationID(handlerBaseName, operationID)
 	g.AddDependencyToHandler(handlerBaseName)
-	g.AddRoute(handlerBaseName, method, pathName)
-	err := g.AddParseParamsMethods(handlerBaseName, contentType, operation)
+	g.AddMock(handlerBaseName)
+	err = g.AddRoute(handlerBaseName, method, pathName)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	g.AddOperationHandlerDecl(handlerBaseName)
+	err = g.AddParseParamsMethods(handlerBaseName, contentTypes, operation)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -256,11 +293,22 @@ func (g *Generator) ProcessApplicationJSONOperation(pathName string, method stri
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	g.AddHandleOperationMethod(handlerBaseName)
-	if operation.RequestBody != nil {
-		g.AddContentTypeToHandler(handlerBaseName, contentType)
+	err = g.AddClientMethod(handlerBaseName, method, pathName, operation)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	g.AddImplMethod(handlerBaseName, operation)
+	err = g.AddSecurity(handlerBaseName, operation)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	g.AddHandleOperationMethod(handlerBaseName, method, pathName, operation)
+	if len(contentTypes) > 0 {
+		for _, contentType := range contentTypes {
+			g.AddContentTypeToHandler(handlerBaseName, method, pathName, contentType)
+		}
 	} else {
-		g.CreateDirectHandler(handlerBaseName)
+		g.CreateDirectHandler(handlerBaseName, method, pathName)
 	}
 
 	return nil
@@ -269,28 +317,9 @@ func (g *Generator) ProcessApplicationJSONOperation(pathName string, method stri
 func (g *Generator) ProcessOperation(pathName string, method string, operation *openapi3.Operation) error {
 	const op = "generator.ProcessOperation"
 
-	if operation.RequestBody != nil {
-		contentKeys := make([]string, 0, len(operation.RequestBody.Value.Content))
-		for contentType := range operation.RequestBody.Value.Content {
-			contentKeys = append(contentKeys, contentType)
-		}
-		sort.Strings(contentKeys)
-		for _, contentType := range contentKeys {
-			switch contentType {
-			case applicationJSONCT:
-				err := g.ProcessApplicationJSONOperation(pathName, method, contentType, operation)
-				if err != nil {
-					return errors.Wrap(err, op)
-				}
-			default:
-				return errors.New("unsupported content type")
-			}
-		}
-	} else {
-		err := g.ProcessApplicationJSONOperation(pathName, method, "", operation)
-		if err != nil {
-			return errors.Wrap(err, op)
-		}
+	err := g.ProcessApplicationJSONOperation(pathName, method, operation)
+	if err != nil {
+		return errors.Wrap(err, op)
 	}
 
 	return nil
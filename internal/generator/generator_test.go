@@ -863,6 +863,163 @@ paths:
       responses:
         '200':
           description: OK
+`,
+		},
+		{
+			name: "TestTypedPathParams",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example/{id}/{uid}/{flag}/{ratio}/{day}/{at}/{amount}:
+    get:
+      operationId: op
+      summary: Example
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            format: int64
+            minimum: 1
+        - name: uid
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: flag
+          in: path
+          required: true
+          schema:
+            type: boolean
+        - name: ratio
+          in: path
+          required: true
+          schema:
+            type: number
+        - name: day
+          in: path
+          required: true
+          schema:
+            type: string
+            format: date
+        - name: at
+          in: path
+          required: true
+          schema:
+            type: string
+            format: date-time
+        - name: amount
+          in: path
+          required: true
+          schema:
+            type: string
+            format: decimal
+      responses:
+        '200':
+          description: OK
+`,
+		},
+		{
+			name: "TestArrayQueryParams",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    get:
+      operationId: op
+      summary: Example
+      parameters:
+        - name: tag
+          in: query
+          required: true
+          schema:
+            type: array
+            minItems: 1
+            items:
+              type: string
+        - name: ids
+          in: query
+          explode: false
+          schema:
+            type: array
+            uniqueItems: true
+            items:
+              type: integer
+              format: int32
+        - name: scores
+          in: query
+          style: spaceDelimited
+          schema:
+            type: array
+            items:
+              type: number
+        - name: days
+          in: query
+          style: pipeDelimited
+          schema:
+            type: array
+            maxItems: 7
+            items:
+              type: string
+              format: date
+      responses:
+        '200':
+          description: OK
+`,
+		},
+		{
+			name: "TestObjectQueryParams",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    get:
+      operationId: op
+      summary: Example
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            required:
+              - status
+            properties:
+              status:
+                type: string
+                enum: [open, closed]
+              owner:
+                type: string
+              limit:
+                type: integer
+                format: int32
+                maximum: 100
+        - name: page
+          in: query
+          required: true
+          schema:
+            $ref: '#/components/schemas/Page'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Page:
+      type: object
+      properties:
+        offset:
+          type: integer
+        size:
+          type: integer
+          minimum: 1
 `,
 		},
 	} {
@@ -927,42 +1084,8 @@ components:
           type: string
 `,
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			input := strings.NewReader(tc.input)
-			outputModels := &bytes.Buffer{}
-			outputHandlers := &bytes.Buffer{}
-			gen := generator.NewGenerator(&options.Options{
-				PackagePrefix: "packagename",
-			})
-			gen.PackageName = "packagename"
-			gen.ImportPrefix = "imports"
-			gen.ModelsImportPath = "packagename/imports/models"
-			err := gen.PrepareAndRead(input)
-			assert.NoError(t, err)
-			err = gen.GenerateFiles()
-			assert.NoError(t, err)
-			err = gen.WriteToOutput(outputModels, outputHandlers)
-			assert.NoError(t, err)
-
-			g := goldie.New(t,
-				goldie.WithFixtureDir("testdata/golden"),
-				goldie.WithNameSuffix(""),
-			)
-			caseName := strings.ReplaceAll(t.Name(), "/", "_")
-			g.Assert(t, caseName+"_models.go", outputModels.Bytes())
-			g.Assert(t, caseName+"_handlers.go", outputHandlers.Bytes())
-		})
-	}
-}
-
-func TestGenerateFeatures2(t *testing.T) {
-	for _, tc := range []struct {
-		name  string
-		input string
-	}{
 		{
-			name: "operation id",
+			name: "numeric bounds",
 			input: `openapi: 3.0.0
 info:
   title: API
@@ -970,8 +1093,13 @@ info:
 paths:
   /example:
     post:
-      operationId: op
       summary: Example
+      parameters:
+        - name: step
+          in: query
+          schema:
+            type: integer
+            multipleOf: 5
       requestBody:
         required: true
         content:
@@ -985,47 +1113,27 @@ components:
   schemas:
     Body:
       type: object
+      required:
+        - price
       properties:
-        message:
+        price:
           type: string
+          format: decimal
+          multipleOf: 0.01
+        quantity:
+          type: integer
+          minimum: 0
+          exclusiveMinimum: true
+        ratio:
+          type: number
+          minimum: 0
+          maximum: 1
+          exclusiveMaximum: true
+          multipleOf: 0.05
 `,
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			input := strings.NewReader(tc.input)
-			outputModels := &bytes.Buffer{}
-			outputHandlers := &bytes.Buffer{}
-			gen := generator.NewGenerator(&options.Options{
-				PackagePrefix: "packagename",
-			})
-			gen.PackageName = "packagename"
-			gen.ImportPrefix = "imports"
-			gen.ModelsImportPath = "packagename/imports/models"
-			err := gen.PrepareAndRead(input)
-			assert.NoError(t, err)
-			err = gen.GenerateFiles()
-			assert.NoError(t, err)
-			err = gen.WriteToOutput(outputModels, outputHandlers)
-			assert.NoError(t, err)
-
-			g := goldie.New(t,
-				goldie.WithFixtureDir("testdata/golden"),
-				goldie.WithNameSuffix(""),
-			)
-			caseName := strings.ReplaceAll(t.Name(), "/", "_")
-			g.Assert(t, caseName+"_models.go", outputModels.Bytes())
-			g.Assert(t, caseName+"_handlers.go", outputHandlers.Bytes())
-		})
-	}
-}
-
-func TestGenerateCookies(t *testing.T) {
-	for _, tc := range []struct {
-		name  string
-		input string
-	}{
 		{
-			name: "operation id",
+			name: "all of",
 			input: `openapi: 3.0.0
 info:
   title: API
@@ -1033,54 +1141,56 @@ info:
 paths:
   /example:
     post:
-      operationId: op
       summary: Example
-      parameters:
-        - name: cookie-field
-          in: cookie
-          schema:
-            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
       responses:
         '200':
           description: OK
+components:
+  schemas:
+    Entity:
+      type: object
+      required:
+        - id
+      properties:
+        id:
+          type: integer
+          format: int64
+        created_at:
+          type: string
+          format: date-time
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Entity'
+        - $ref: 'testdata/def.yml#/components/schemas/ExternalBase'
+        - type: object
+          required:
+            - name
+          properties:
+            name:
+              type: string
+              minLength: 1
+            tag:
+              type: string
+              nullable: true
+            details:
+              allOf:
+                - $ref: '#/components/schemas/Entity'
+      required:
+        - tag
+      properties:
+        id:
+          type: integer
+          format: int64
 `,
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			input := strings.NewReader(tc.input)
-			outputModels := &bytes.Buffer{}
-			outputHandlers := &bytes.Buffer{}
-			gen := generator.NewGenerator(&options.Options{
-				PackagePrefix: "packagename",
-			})
-			gen.PackageName = "packagename"
-			gen.ImportPrefix = "imports"
-			gen.ModelsImportPath = "packagename/imports/models"
-			err := gen.PrepareAndRead(input)
-			assert.NoError(t, err)
-			err = gen.GenerateFiles()
-			assert.NoError(t, err)
-			err = gen.WriteToOutput(outputModels, outputHandlers)
-			assert.NoError(t, err)
-
-			g := goldie.New(t,
-				goldie.WithFixtureDir("testdata/golden"),
-				goldie.WithNameSuffix(""),
-			)
-			caseName := strings.ReplaceAll(t.Name(), "/", "_")
-			g.Assert(t, caseName+"_models.go", outputModels.Bytes())
-			g.Assert(t, caseName+"_handlers.go", outputHandlers.Bytes())
-		})
-	}
-}
-
-func TestGenerateExternal(t *testing.T) {
-	for _, tc := range []struct {
-		name  string
-		input string
-	}{
 		{
-			name: "operation id",
+			name: "one of",
 			input: `openapi: 3.0.0
 info:
   title: API
@@ -1088,29 +1198,609 @@ info:
 paths:
   /example:
     post:
-      operationId: op
       summary: Example
       requestBody:
         required: true
         content:
           application/json:
             schema:
-              type: object
-              properties:
-                external-ref:
-                  $ref: 'testdata/def.yml#/components/schemas/ExternalRef'
+              $ref: '#/components/schemas/Shape'
       responses:
         '200':
           description: OK
-`,
-		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			input := strings.NewReader(tc.input)
-			outputModels := &bytes.Buffer{}
-			outputHandlers := &bytes.Buffer{}
-			gen := generator.NewGenerator(&options.Options{
+components:
+  schemas:
+    Circle:
+      type: object
+      required:
+        - kind
+        - radius
+      properties:
+        kind:
+          type: string
+        radius:
+          type: number
+    Square:
+      type: object
+      required:
+        - kind
+        - side
+      properties:
+        kind:
+          type: string
+        side:
+          type: number
+    Shape:
+      oneOf:
+        - $ref: '#/components/schemas/Circle'
+        - $ref: '#/components/schemas/Square'
+      discriminator:
+        propertyName: kind
+        mapping:
+          circle: '#/components/schemas/Circle'
+    Drawing:
+      type: object
+      properties:
+        shape:
+          oneOf:
+            - $ref: '#/components/schemas/Circle'
+            - $ref: '#/components/schemas/Square'
+        label:
+          anyOf:
+            - type: string
+            - type: object
+              required:
+                - text
+              properties:
+                text:
+                  type: string
+`,
+		},
+		{
+			name: "additional properties",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      summary: Example
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Config'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Entry:
+      type: object
+      required:
+        - value
+      properties:
+        value:
+          type: string
+      additionalProperties: false
+    Config:
+      type: object
+      properties:
+        name:
+          type: string
+        entries:
+          type: object
+          additionalProperties:
+            $ref: '#/components/schemas/Entry'
+        tags:
+          type: object
+          minProperties: 1
+          additionalProperties:
+            type: string
+            maxLength: 10
+        metadata:
+          type: object
+          additionalProperties: true
+      additionalProperties:
+        type: object
+        properties:
+          enabled:
+            type: boolean
+    Extras:
+      type: object
+      additionalProperties: {}
+`,
+		},
+		{
+			name: "pattern",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example/{slug}:
+    post:
+      summary: Example
+      parameters:
+        - name: slug
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: '^[a-z0-9-]+$'
+        - name: code
+          in: query
+          schema:
+            type: string
+            pattern: '^[A-Z]{3}$'
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Body'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Slug:
+      type: string
+      pattern: '^[a-z0-9-]+$'
+    Body:
+      type: object
+      required:
+        - slug
+      properties:
+        slug:
+          $ref: '#/components/schemas/Slug'
+        tags:
+          type: array
+          items:
+            type: string
+            pattern: '^#\w+$'
+`,
+		},
+		{
+			name: "pattern on typed format",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      summary: Example
+      parameters:
+        - name: since
+          in: query
+          schema:
+            type: string
+            format: date
+            pattern: '^2024-'
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Key'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Key:
+      type: object
+      required:
+        - id
+      properties:
+        id:
+          type: string
+          format: date-time
+          pattern: '^2024-'
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			g := goldie.New(t,
+				goldie.WithFixtureDir("testdata/golden"),
+				goldie.WithNameSuffix(""),
+			)
+			caseName := strings.ReplaceAll(t.Name(), "/", "_")
+			g.Assert(t, caseName+"_models.go", outputModels.Bytes())
+			g.Assert(t, caseName+"_handlers.go", outputHandlers.Bytes())
+		})
+	}
+}
+
+func TestGenerateFeatures2(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "operation id",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      operationId: op
+      summary: Example
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Body'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Body:
+      type: object
+      properties:
+        message:
+          type: string
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			g := goldie.New(t,
+				goldie.WithFixtureDir("testdata/golden"),
+				goldie.WithNameSuffix(""),
+			)
+			caseName := strings.ReplaceAll(t.Name(), "/", "_")
+			g.Assert(t, caseName+"_models.go", outputModels.Bytes())
+			g.Assert(t, caseName+"_handlers.go", outputHandlers.Bytes())
+		})
+	}
+}
+
+func TestGenerateCookies(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "operation id",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      operationId: op
+      summary: Example
+      parameters:
+        - name: cookie-field
+          in: cookie
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			g := goldie.New(t,
+				goldie.WithFixtureDir("testdata/golden"),
+				goldie.WithNameSuffix(""),
+			)
+			caseName := strings.ReplaceAll(t.Name(), "/", "_")
+			g.Assert(t, caseName+"_models.go", outputModels.Bytes())
+			g.Assert(t, caseName+"_handlers.go", outputHandlers.Bytes())
+		})
+	}
+}
+
+func TestGenerateExternal(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "operation id",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      operationId: op
+      summary: Example
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                external-ref:
+                  $ref: 'testdata/def.yml#/components/schemas/ExternalRef'
+      responses:
+        '200':
+          description: OK
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			g := goldie.New(t,
+				goldie.WithFixtureDir("testdata/golden"),
+				goldie.WithNameSuffix(""),
+			)
+			caseName := strings.ReplaceAll(t.Name(), "/", "_")
+			g.Assert(t, caseName+"_models.go", outputModels.Bytes())
+			g.Assert(t, caseName+"_handlers.go", outputHandlers.Bytes())
+		})
+	}
+}
+
+func TestGenerateUnsupportedPattern(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Word:
+      type: string
+      pattern: '^(?!admin)\w+$'
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "#/components/schemas/Word")
+	assert.ErrorContains(t, err, "RE2")
+}
+
+func TestGeneratePatternTagCollision(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Codes:
+      type: object
+      properties:
+        first:
+          type: string
+          pattern: '^a522789$'
+        second:
+          type: string
+          pattern: '^a739192$'
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, `patterns "^a522789$" and "^a739192$" share the validator tag pattern_3e3f227d`)
+}
+
+func TestGenerateAllOfConflict(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Entity:
+      type: object
+      properties:
+        id:
+          type: integer
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Entity'
+        - type: object
+          properties:
+            id:
+              type: string
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "allOf members of Pet declare property id as both int and string")
+}
+
+func TestGenerateStrict(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		strict bool
+		input  string
+	}{
+		{
+			name:   "flag",
+			strict: true,
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      summary: Example
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        customer:
+          type: object
+          properties:
+            email:
+              type: string
+        lines:
+          type: array
+          items:
+            $ref: '#/components/schemas/Line'
+        metadata:
+          $ref: '#/components/schemas/Metadata'
+    Line:
+      type: object
+      properties:
+        sku:
+          type: string
+    Metadata:
+      type: object
+      x-strict: false
+      properties:
+        source:
+          type: string
+`,
+		},
+		{
+			name: "extension",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      summary: Example
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Order:
+      type: object
+      x-strict: true
+      properties:
+        customer:
+          type: object
+          properties:
+            email:
+              type: string
+        metadata:
+          $ref: '#/components/schemas/Metadata'
+    Metadata:
+      type: object
+      properties:
+        source:
+          type: string
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
 				PackagePrefix: "packagename",
+				StrictJSON:    tc.strict,
 			})
 			gen.PackageName = "packagename"
 			gen.ImportPrefix = "imports"
@@ -1132,3 +1822,332 @@ paths:
 		})
 	}
 }
+
+func TestGenerateServeMux(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items/:
+    get:
+      operationId: list-items
+      responses:
+        '200':
+          description: OK
+  /items/{item-id}:
+    get:
+      operationId: get-item
+      parameters:
+        - name: item-id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+`)
+	outputModels := &bytes.Buffer{}
+	outputHandlers := &bytes.Buffer{}
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+		Router:        options.RouterServeMux,
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+	err = gen.GenerateFiles()
+	assert.NoError(t, err)
+	err = gen.WriteToOutput(outputModels, outputHandlers)
+	assert.NoError(t, err)
+
+	g := goldie.New(t,
+		goldie.WithFixtureDir("testdata/golden"),
+		goldie.WithNameSuffix(""),
+	)
+	g.Assert(t, t.Name()+"_handlers.go", outputHandlers.Bytes())
+}
+
+func TestGenerateServeMuxUnsupportedPath(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /files/{name}.json:
+    get:
+      operationId: get-file
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+		Router:        options.RouterServeMux,
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "path /files/{name}.json: segment {name}.json is not a single parameter")
+}
+
+func TestGenerateServers(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/api/{version}/
+    variables:
+      region:
+        default: eu
+      version:
+        default: v2
+  - url: /api/v2
+  - url: http://localhost:8080
+paths:
+  /items:
+    get:
+      operationId: list-items
+      responses:
+        '200':
+          description: OK
+`)
+	outputModels := &bytes.Buffer{}
+	outputHandlers := &bytes.Buffer{}
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+	err = gen.GenerateFiles()
+	assert.NoError(t, err)
+	err = gen.WriteToOutput(outputModels, outputHandlers)
+	assert.NoError(t, err)
+
+	g := goldie.New(t,
+		goldie.WithFixtureDir("testdata/golden"),
+		goldie.WithNameSuffix(""),
+	)
+	g.Assert(t, t.Name()+"_handlers.go", outputHandlers.Bytes())
+}
+
+func TestGenerateUnsupportedSecurityScheme(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+security:
+  - digest: []
+paths:
+  /items:
+    get:
+      operationId: list-items
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    digest:
+      type: http
+      scheme: digest
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "security scheme digest: http digest is not supported")
+}
+
+func TestGenerateUnsupportedFormBody(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    post:
+      operationId: create-item
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                owner:
+                  type: object
+                  properties:
+                    name:
+                      type: string
+      responses:
+        '204':
+          description: Created
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "property owner of the form body of CreateItem must be a scalar or an array")
+}
+
+func TestGenerateFileInUrlencodedBody(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    post:
+      operationId: create-item
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                picture:
+                  type: string
+                  format: binary
+      responses:
+        '204':
+          description: Created
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "file property picture of the form body of CreateItem requires multipart/form-data")
+}
+
+func TestGenerateConflictingRequestContentTypes(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    patch:
+      operationId: update-item
+      requestBody:
+        content:
+          application/merge-patch+json:
+            schema:
+              type: object
+          application/vnd.merge-patch+json:
+            schema:
+              type: object
+      responses:
+        '204':
+          description: Updated
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "content types application/merge-patch+json and application/vnd.merge-patch+json of UpdateItem share the body field MergePatchJSONBody")
+}
+
+func TestGenerateUnsupportedResponseContentType(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      operationId: list-items
+      responses:
+        '200':
+          description: Items
+          content:
+            application/json:
+              schema:
+                type: object
+            text/csv:
+              schema:
+                type: object
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "content type text/csv of ListItems response 200 needs a string schema")
+}
+
+func TestGenerateUnsupportedBinaryMediaRange(t *testing.T) {
+	input := strings.NewReader(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /images:
+    post:
+      operationId: post-image
+      requestBody:
+        content:
+          image/*:
+            schema:
+              type: string
+              format: binary
+      responses:
+        '204':
+          description: Stored
+`)
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.PackageName = "packagename"
+	gen.ImportPrefix = "imports"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(input)
+	assert.NoError(t, err)
+
+	err = gen.GenerateFiles()
+	assert.ErrorContains(t, err, "unsupported content type image/* of PostImage")
+}
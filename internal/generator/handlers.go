@@ -28,15 +28,32 @@ type HandlersFile struct {
 	handlerConstructorDeclQAConstructorComposite *ast.CompositeLit // quick access to handler struct initializer
 
 	addRoutesDecl         *ast.FuncDecl
+	basePaths             []string // base paths of the servers, "" for the root
+	routerDecls           []ast.Decl
+	operationIDs          []ast.Spec
+	secured               map[string]bool // operations with security requirements
+	multipart             map[string]bool // operations reading multipart/form-data bodies
+	urlencoded            map[string]bool // operations reading application/x-www-form-urlencoded bodies
+	negotiated            map[string]bool // operations accepting several request content types
+	securitySchemes       []securityScheme
 	handleDeclQASwitches  map[string]*ast.BlockStmt
 	restDecls             []*ast.FuncDecl
 	extraDecls            []ast.Decl
 	hasContainsNullMethod bool
+	patterns              map[string]string // validator tag -> pattern
+	hasMultipleOf         bool
+	strictSchemas         map[*openapi3.Schema]bool // schemas rejecting unknown properties
+	hasValidationErrors   bool
+	// some response has several content types, negotiated on the Accept header
+	hasNegotiatedResponses bool
+	// some request or response body is read or written as is
+	hasBinaryBodies bool
+	// operation -> media type a request body without Content-Type is read as
+	defaultContentTypes map[string]string
 }
 
 func (g *Generator) InitHandlerImports() {
 	g.AddHandlersImport("github.com/go-playground/validator/v10")
-	g.AddHandlersImport("github.com/go-chi/chi/v5")
 }
 
 func (g *Generator) InitHandlerStruct() {
@@ -89,10 +106,27 @@ func (g *Generator) InitHandlerConstructor() {
 }
 
 func (g *Generator) FinalizeHandlerConstructor() {
-	// 1. Append `errorHandler ErrorHandler` to the Handler struct.
+	g.addAuthenticatorDependencies()
+	g.addTelemetryDependencies()
+	g.addMultipartDependencies()
+	g.addFormDependencies()
+
+	// 1. Append `errorHandler ErrorHandler`,
+	//    `validationErrorHandler ValidationErrorHandler` and
+	//    `validateResponses bool` to the Handler struct.
 	g.HandlersFile.handlerDeclQAFieldList.List = append(
 		g.HandlersFile.handlerDeclQAFieldList.List,
 		Field("errorHandler", I("ErrorHandler"), ""),
+		Field("validationErrorHandler", I("ValidationErrorHandler"), ""),
+		Field("validateResponses", I("bool"), ""),
+		Field("router", Sel(I("http"), "Handler"), ""),
+		Field("middlewares", &ast.MapType{
+			Key: I("OperationID"),
+			Value: &ast.ArrayType{Elt: &ast.FuncType{
+				Params:  &ast.FieldList{List: FieldA(Field("", Sel(I("http"), "Handler"), ""))},
+				Results: &ast.FieldList{List: FieldA(Field("", Sel(I("http"), "Handler"), ""))},
+			}},
+		}, ""),
 	)
 
 	// 2. Append `errorHandler: DefaultErrorHandler` to the composite literal.
@@ -113,39 +147,72 @@ func (g *Generator) FinalizeHandlerConstructor() {
 	// 4. Rewrite the body from `return &Handler{...}` to
 	//        h := &Handler{...}
 	//        for _, opt := range opts { opt(h) }
+	//        h.telemetry = newTelemetry(...) with -otel
+	//        h.router = h.Router()
 	//        return h
 	initializer := g.HandlersFile.handlerConstructorDeclQAConstructorComposite
-	g.HandlersFile.handlerConstructorDecl.Body.List = []ast.Stmt{
+	body := []ast.Stmt{
 		&ast.AssignStmt{
 			Lhs: []ast.Expr{I("h")},
 			Tok: token.DEFINE,
 			Rhs: []ast.Expr{Amp(initializer)},
 		},
-		&ast.RangeStmt{
-			Key:   I("_"),
-			Value: I("opt"),
-			Tok:   token.DEFINE,
-			X:     I("opts"),
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ExprStmt{
-						X: &ast.CallExpr{
-							Fun:  I("opt"),
-							Args: []ast.Expr{I("h")},
-						},
+		// validation errors point to fields by their JSON names
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun:  Sel(Sel(I("h"), "validator"), "RegisterTagNameFunc"),
+				Args: []ast.Expr{I("jsonTagName")},
+			},
+		},
+	}
+	if len(g.HandlersFile.patterns) > 0 {
+		body = append(body, &ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun:  I("registerPatternValidators"),
+				Args: []ast.Expr{Sel(I("h"), "validator")},
+			},
+		})
+	}
+	if g.HandlersFile.hasMultipleOf {
+		body = append(body, &ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun:  I("registerMultipleOfValidator"),
+				Args: []ast.Expr{Sel(I("h"), "validator")},
+			},
+		})
+	}
+	body = append(body, &ast.RangeStmt{
+		Key:   I("_"),
+		Value: I("opt"),
+		Tok:   token.DEFINE,
+		X:     I("opts"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun:  I("opt"),
+						Args: []ast.Expr{I("h")},
 					},
 				},
 			},
 		},
+	})
+	body = append(body, g.telemetryInitStmts()...)
+	g.HandlersFile.handlerConstructorDecl.Body.List = append(body,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I("h"), "router")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("h"), "Router")}},
+		},
 		Ret1(I("h")),
-	}
+	)
 }
 
 func (g *Generator) InitRoutesFunc() {
 	g.HandlersFile.addRoutesDecl = Func(
 		"AddRoutes",
 		Field("h", Star(I("Handler")), ""),
-		FieldA(Field("router", Sel(I("chi"), "Router"), "")),
+		FieldA(Field("router", g.routerType(), "")),
 		nil,
 		[]ast.Stmt{},
 	)
@@ -166,6 +233,13 @@ func (g *Generator) InitHandlerFields(packageName string) {
 func (g *Generator) NewHandlersFile() {
 	g.HandlersFile = &HandlersFile{
 		requiredFieldsArePointers: g.Opts.RequiredFieldsArePointers,
+		patterns:                  make(map[string]string),
+		strictSchemas:             make(map[*openapi3.Schema]bool),
+		secured:                   make(map[string]bool),
+		multipart:                 make(map[string]bool),
+		urlencoded:                make(map[string]bool),
+		negotiated:                make(map[string]bool),
+		defaultContentTypes:       make(map[string]string),
 	}
 }
 
@@ -273,7 +347,11 @@ func (g *Generator) GenerateImportsSpecs(imp []string) ([]*ast.ImportSpec, []ast
 	// Add a space to separate system and library imports
 	// but go/ast is too great for that
 	for _, path := range libImports {
-		specs = append(specs, &ast.ImportSpec{Path: Str(path)})
+		spec := &ast.ImportSpec{Path: Str(path)}
+		if name, ok := importNames[path]; ok {
+			spec.Name = I(name)
+		}
+		specs = append(specs, spec)
 	}
 
 	// Add a space to separate library and user imports
@@ -294,7 +372,20 @@ func (g *Generator) GenerateHandlersFile() *ast.File {
 	if len(g.HandlersFile.addRoutesDecl.Body.List) > 0 {
 		g.FinalizeHandlerConstructor()
 		g.AddStandardErrorDecls()
+		g.AddValidationErrorHandlerDecls()
+		g.AddResponseValidationDecls()
+		g.AddOperationMiddlewareDecls()
+		g.AddSecurityDecls()
+		g.AddTelemetryDecls()
+		g.AddMultipartDecls()
+		g.AddFormDecls()
+		g.AddMediaTypeDecls()
+		g.AddBinaryDecls()
+		g.AddPatternValidators()
+		g.AddMultipleOfValidator()
+		g.HandlersFile.routerDecls = append(g.HandlersFile.routerDecls, g.routerDecls()...)
 	}
+	g.AddValidationErrorDecls()
 
 	importSpecs, declSpecs := g.GenerateImportsSpecs(g.HandlersFile.packageImports)
 
@@ -313,10 +404,18 @@ func (g *Generator) GenerateHandlersFile() *ast.File {
 	for _, d := range g.HandlersFile.interfaceDecls {
 		file.Decls = append(file.Decls, d)
 	}
+	if len(g.HandlersFile.operationIDs) > 0 {
+		file.Decls = append(file.Decls, &ast.GenDecl{
+			Tok:    token.CONST,
+			Lparen: 1,
+			Specs:  g.HandlersFile.operationIDs,
+		})
+	}
 
 	file.Decls = append(file.Decls, g.HandlersFile.handlerDecl)
 	file.Decls = append(file.Decls, g.HandlersFile.handlerConstructorDecl)
 	file.Decls = append(file.Decls, g.HandlersFile.addRoutesDecl)
+	file.Decls = append(file.Decls, g.HandlersFile.routerDecls...)
 	for _, d := range g.HandlersFile.restDecls {
 		file.Decls = append(file.Decls, d)
 	}
@@ -325,16 +424,14 @@ func (g *Generator) GenerateHandlersFile() *ast.File {
 	return file
 }
 
-func (g *Generator) AddRouteToRouter(baseName string, method string, pathName string) {
-	g.HandlersFile.addRoutesDecl.Body.List = append(g.HandlersFile.addRoutesDecl.Body.List, &ast.ExprStmt{
-		X: &ast.CallExpr{
-			Fun: Sel(I("router"), method),
-			Args: []ast.Expr{
-				Str(pathName),
-				Sel(I("h"), "handle"+baseName),
-			},
-		},
-	})
+func (g *Generator) AddRouteToRouter(baseName string, method string, pathName string) error {
+	route, err := g.routeStmt(baseName, method, pathName)
+	if err != nil {
+		return err
+	}
+	g.HandlersFile.addRoutesDecl.Body.List = append(g.HandlersFile.addRoutesDecl.Body.List, route)
+
+	return nil
 }
 
 func (g *Generator) GetHandler(baseName string) *ast.BlockStmt {
@@ -348,7 +445,7 @@ func (g *Generator) GetHandler(baseName string) *ast.BlockStmt {
 	return nil
 }
 
-func (g *Generator) CreateHandler(baseName string) {
+func (g *Generator) CreateHandler(baseName string, method string, pathName string) {
 	g.AddHandlersImport("mime")
 
 	switchBody := &ast.BlockStmt{
@@ -363,7 +460,7 @@ func (g *Generator) CreateHandler(baseName string) {
 			Field("r", Star(Sel(I("http"), "Request")), ""),
 		},
 		nil,
-		[]ast.Stmt{
+		append(g.instrumentStmts(baseName, method, pathName),
 			// contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
 			&ast.AssignStmt{
 				Lhs: []ast.Expr{
@@ -388,7 +485,7 @@ func (g *Generator) CreateHandler(baseName string) {
 				Tag:  I("contentType"),
 				Body: switchBody,
 			},
-		},
+		),
 	)
 
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, handleFunc)
@@ -402,7 +499,7 @@ func (g *Generator) CreateHandler(baseName string) {
 // CreateDirectHandler generates a handler that directly delegates to the request handler
 // without checking Content-Type. Used for operations with no request body (e.g. GET, DELETE)
 // where Content-Type is irrelevant.
-func (g *Generator) CreateDirectHandler(baseName string) {
+func (g *Generator) CreateDirectHandler(baseName string, method string, pathName string) {
 	handleFunc := Func(
 		"handle"+baseName,
 		Field("h", Star(I("Handler")), ""),
@@ -411,14 +508,14 @@ func (g *Generator) CreateDirectHandler(baseName string) {
 			Field("r", Star(Sel(I("http"), "Request")), ""),
 		},
 		nil,
-		[]ast.Stmt{
+		append(g.instrumentStmts(baseName, method, pathName),
 			&ast.ExprStmt{
 				X: &ast.CallExpr{
 					Fun:  Sel(I("h"), "handle"+baseName+"Request"),
 					Args: []ast.Expr{I("w"), I("r")},
 				},
 			},
-		},
+		),
 	)
 
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, handleFunc)
@@ -444,18 +541,34 @@ func (g *Generator) AddContentTypeHandler(baseName string, rawContentType string
 		return
 	}
 	if blockStmt, ok := g.HandlersFile.handleDeclQASwitches[baseName]; ok {
+		args := []ast.Expr{
+			I("w"),
+			I("r"),
+		}
+		if g.HandlersFile.negotiated[baseName] {
+			args = append(args, Str(rawContentType))
+		}
 		stmts := []ast.Stmt{
 			&ast.ExprStmt{
 				X: &ast.CallExpr{
-					Fun: Sel(I("h"), "handle"+baseName+"Request"),
-					Args: []ast.Expr{
-						I("w"),
-						I("r"),
-					},
+					Fun:  Sel(I("h"), "handle"+baseName+"Request"),
+					Args: args,
 				},
 			},
 			Ret(),
 		}
+		if rawContentType == multipartFormCT {
+			stmts = append([]ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  Sel(I("h"), "limitMultipartBody"),
+				Args: []ast.Expr{I("w"), I("r")},
+			}}}, stmts...)
+		}
+		if rawContentType == applicationFormCT {
+			stmts = append([]ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  Sel(I("h"), "limitFormBody"),
+				Args: []ast.Expr{I("w"), I("r")},
+			}}}, stmts...)
+		}
 
 		blockStmt.List = append(blockStmt.List, &ast.CaseClause{
 			List: []ast.Expr{Str(rawContentType)},
@@ -463,7 +576,7 @@ func (g *Generator) AddContentTypeHandler(baseName string, rawContentType string
 		},
 		)
 
-		if rawContentType == applicationJSONCT {
+		if rawContentType == g.HandlersFile.defaultContentTypes[baseName] {
 			blockStmt.List = append(blockStmt.List, &ast.CaseClause{
 				List: []ast.Expr{Str("")},
 				Body: stmts,
@@ -472,95 +585,126 @@ func (g *Generator) AddContentTypeHandler(baseName string, rawContentType string
 	}
 }
 
-func (g *Generator) AddHandleOperationMethodHandlers(baseName string) {
-	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func(
-		"handle"+baseName+"Request",
-		Field("h", Star(I("Handler")), ""),
-		[]*ast.Field{
-			Field("w", Sel(I("http"), "ResponseWriter"), ""),
-			Field("r", Star(Sel(I("http"), "Request")), ""),
+func (g *Generator) AddHandleOperationMethodHandlers(baseName string, method string, pathName string, operation *openapi3.Operation) {
+	params := []*ast.Field{
+		Field("w", Sel(I("http"), "ResponseWriter"), ""),
+		Field("r", Star(Sel(I("http"), "Request")), ""),
+	}
+	parseArgs := []ast.Expr{
+		I("r"),
+	}
+	if g.HandlersFile.negotiated[baseName] {
+		// the media type the handle<Op> switch matched
+		params = append(params, Field("contentType", I("string"), ""))
+		parseArgs = append(parseArgs, I("contentType"))
+	}
+	if g.HandlersFile.multipart[baseName] {
+		parseArgs = append(parseArgs, Amp(I("opened")))
+	}
+	parse := append(g.authenticateStmts(baseName), g.openedFilesDecl(baseName)...)
+	parse = append(parse, &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			I("request"),
+			I("err"),
 		},
-		nil,
-		[]ast.Stmt{
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					I("request"),
-					I("err"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: Sel(I("h"), "parse"+baseName+"Request"),
-						Args: []ast.Expr{
-							I("r"),
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun:  Sel(I("h"), "parse"+baseName+"Request"),
+				Args: parseArgs,
+			},
+		},
+	})
+	parse = append(parse, g.removeMultipartFormStmts(baseName)...)
+	body := append(parse,
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{
+				List: append(g.recordValidationErrorStmts(baseName),
+					&ast.ExprStmt{
+						X: &ast.CallExpr{
+							Fun:  Sel(I("h"), "handleValidationError"),
+							Args: []ast.Expr{I("w"), I("r"), I("err")},
 						},
 					},
-				},
+					Ret(),
+				),
 			},
-			&ast.IfStmt{
-				Cond: Ne(I("err"), I("nil")),
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						writeStandardErrorCall("StatusBadRequest", &ast.CallExpr{
-							Fun: Sel(I("err"), "Error"),
-						}),
-						Ret(),
-					},
-				},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				I("ctx"),
 			},
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					I("ctx"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun:  Sel(I("r"), "Context"),
-						Args: []ast.Expr{},
-					},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun:  Sel(I("r"), "Context"),
+					Args: []ast.Expr{},
 				},
 			},
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					I("response"),
-					I("err"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: Sel(Sel(I("h"), GoIdentLowercase(baseName)), "Handle"+baseName),
-						Args: []ast.Expr{
-							I("ctx"),
-							Star(I("request")),
-						},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				I("response"),
+				I("err"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: Sel(Sel(I("h"), GoIdentLowercase(baseName)), "Handle"+baseName),
+					Args: []ast.Expr{
+						I("ctx"),
+						Star(I("request")),
 					},
 				},
 			},
-			&ast.IfStmt{
-				Cond: &ast.BinaryExpr{
-					X:  Ne(I("err"), I("nil")),
-					Op: token.LOR,
-					Y:  Eq(I("response"), I("nil")),
+		},
+	)
+	body = append(body, closeFailedResponseStmts(sortedKeys(operation.Responses.Map()), operation)...)
+	body = append(body,
+		&ast.IfStmt{
+			Cond: &ast.CallExpr{
+				Fun:  Sel(I("errors"), "Is"),
+				Args: []ast.Expr{I("err"), I("ErrNotImplemented")},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					writeStandardErrorCall("StatusNotImplemented", Str("Not Implemented")),
+					Ret(),
 				},
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						writeStandardErrorCall("StatusInternalServerError", Str("Internal Server Error")),
-						Ret(),
-					},
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  Ne(I("err"), I("nil")),
+				Op: token.LOR,
+				Y:  Eq(I("response"), I("nil")),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					writeStandardErrorCall("StatusInternalServerError", Str("Internal Server Error")),
+					Ret(),
 				},
 			},
-			&ast.ExprStmt{
-				X: &ast.CallExpr{
-					Fun: Sel(I("h"), "write"+baseName+"Response"),
-					Args: []ast.Expr{
-						I("w"),
-						I("r"),
-						I("response"),
-					},
+		},
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: Sel(I("h"), "write"+baseName+"Response"),
+				Args: []ast.Expr{
+					I("w"),
+					I("r"),
+					I("response"),
 				},
 			},
-			Ret(),
 		},
+		Ret(),
+	)
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func(
+		"handle"+baseName+"Request",
+		Field("h", Star(I("Handler")), ""),
+		params,
+		nil,
+		body,
 	))
 }
 
@@ -581,6 +725,13 @@ func (g *Generator) AddWriteResponseMethodHandlers(baseName string, codes []stri
 				},
 			},
 		})
+		if g.AddValidateResponseCodeMethod(baseName, code, response) {
+			caseBody = append(caseBody, validateResponseStmt(baseName, code))
+		}
+		contentTypes := sortedKeys(response.Value.Content)
+		if len(contentTypes) > 1 {
+			caseBody = append(caseBody, negotiateResponseStmts(code, contentTypes)...)
+		}
 
 		if len(response.Value.Headers) > 0 {
 			caseBody = append(caseBody,
@@ -596,49 +747,31 @@ func (g *Generator) AddWriteResponseMethodHandlers(baseName string, codes []stri
 				})
 		}
 
-		if len(response.Value.Content) > 0 {
-			if len(response.Value.Content) > 1 {
-				return errors.New("multiple content types are not supported for response code " + code)
-			}
-			var contentType string
-			for key := range response.Value.Content {
-				contentType = key
-				break
+		switch len(contentTypes) {
+		case 0:
+			caseBody = append(caseBody, writeStatusAndBodyStmts("write"+baseName+code+"Response", code)...)
+		case 1:
+			caseBody = append(caseBody, g.setResponseContentTypeStmt(contentTypes[0], response.Value.Content[contentTypes[0]],
+				Amp(Sel(Sel(I("response"), "Response"+code), "Body")),
+			))
+			caseBody = append(caseBody, writeStatusAndBodyStmts("write"+baseName+code+"Response", code)...)
+		default:
+			clauses := make([]ast.Stmt, 0, len(contentTypes))
+			for _, contentType := range contentTypes {
+				clauses = append(clauses, &ast.CaseClause{
+					List: []ast.Expr{Str(contentType)},
+					Body: append([]ast.Stmt{g.setResponseContentTypeStmt(contentType, response.Value.Content[contentType],
+						Sel(Sel(I("response"), "Response"+code), responseBodyField(contentType, contentTypes)),
+					)},
+						writeStatusAndBodyStmts("write"+baseName+code+bodyVariant(contentType)+"Response", code)...,
+					),
+				})
 			}
-			caseBody = append(caseBody,
-				&ast.ExprStmt{
-					X: &ast.CallExpr{
-						Fun: Sel(&ast.CallExpr{
-							Fun:  Sel(I("w"), "Header"),
-							Args: []ast.Expr{},
-						}, "Set"),
-						Args: []ast.Expr{
-							Str("Content-Type"),
-							Str(g.getContentTypeHeadeValue(contentType)),
-						},
-					},
-				},
-			)
-
+			caseBody = append(caseBody, &ast.SwitchStmt{
+				Tag:  I("contentType"),
+				Body: &ast.BlockStmt{List: clauses},
+			})
 		}
-
-		caseBody = append(caseBody, &ast.ExprStmt{
-			X: &ast.CallExpr{
-				Fun:  Sel(I("w"), "WriteHeader"),
-				Args: []ast.Expr{Sel(I("response"), "StatusCode")},
-			},
-		})
-		caseBody = append(caseBody, &ast.ExprStmt{
-			X: &ast.CallExpr{
-				Fun: Sel(I("h"), "write"+baseName+code+"Response"),
-				Args: []ast.Expr{
-					I("w"),
-					I("r"),
-					Sel(I("response"), "Response"+code),
-				},
-			},
-		})
-		caseBody = append(caseBody, &ast.ReturnStmt{})
 		switchBody.List = append(switchBody.List, &ast.CaseClause{
 			List: []ast.Expr{
 				&ast.BasicLit{
@@ -659,22 +792,131 @@ func (g *Generator) AddWriteResponseMethodHandlers(baseName string, codes []stri
 			Field("response", Star(Sel(I(g.GetCurrentModelsPackage()), baseName+"Response")), ""),
 		},
 		nil,
-		[]ast.Stmt{
+		append(closeBinaryBodiesStmts(codes, operation, true),
 			&ast.SwitchStmt{
 				Tag:  Sel(I("response"), "StatusCode"),
 				Body: switchBody,
 			},
 			writeStandardErrorCall("StatusInternalServerError", Str("Internal Server Error")),
-		},
+		),
 	)
 
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, writeResponseFunc)
 	return nil
 }
 
+// negotiateResponseStmts returns the statements choosing contentType among the
+// bodies of the response set by the handler, by the Accept header of the
+// request, and reporting 406 to the error handler when it accepts none.
+func negotiateResponseStmts(code string, contentTypes []string) []ast.Stmt {
+	stmts := []ast.Stmt{&ast.DeclStmt{Decl: &ast.GenDecl{
+		Tok:   token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I("offers")}, Type: &ast.ArrayType{Elt: I("string")}}},
+	}}}
+	for _, contentType := range contentTypes {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: Ne(Sel(Sel(I("response"), "Response"+code), responseBodyField(contentType, contentTypes)), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{I("offers")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: I("append"), Args: []ast.Expr{I("offers"), Str(contentType)}}},
+			}}},
+		})
+	}
+
+	return append(stmts,
+		&ast.IfStmt{
+			Cond: Eq(&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I("offers")}}, intLit("0")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				writeStandardErrorCall("StatusInternalServerError", Str("Internal Server Error")),
+				Ret(),
+			}},
+		},
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(&ast.CallExpr{Fun: Sel(I("w"), "Header")}, "Add"),
+			Args: []ast.Expr{Str("Vary"), Str("Accept")},
+		}},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("contentType")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:      I("negotiateContentType"),
+				Args:     []ast.Expr{I("r"), I("offers")},
+				Ellipsis: 1,
+			}},
+		},
+		&ast.IfStmt{
+			Cond: Eq(I("contentType"), Str("")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				writeStandardErrorCall("StatusNotAcceptable", Str("Not Acceptable")),
+				Ret(),
+			}},
+		},
+	)
+}
+
+// setResponseContentTypeStmt sets the headers of body, the response body of the
+// media type: the ones of a binary body, the Content-Type of the media type
+// otherwise.
+func (g *Generator) setResponseContentTypeStmt(contentType string, content *openapi3.MediaType, body ast.Expr) ast.Stmt {
+	if !isBinaryContent(contentType, content) {
+		return setContentTypeStmt(g.getContentTypeHeadeValue(contentType))
+	}
+
+	return &ast.ExprStmt{X: &ast.CallExpr{
+		Fun: I("setBinaryBodyHeaders"),
+		Args: []ast.Expr{
+			&ast.CallExpr{Fun: Sel(I("w"), "Header")},
+			body,
+			Str(binaryContentType(contentType)),
+		},
+	}}
+}
+
+// setContentTypeStmt sets the Content-Type header of the response to value.
+func setContentTypeStmt(value string) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: Sel(&ast.CallExpr{
+				Fun:  Sel(I("w"), "Header"),
+				Args: []ast.Expr{},
+			}, "Set"),
+			Args: []ast.Expr{
+				Str("Content-Type"),
+				Str(value),
+			},
+		},
+	}
+}
+
+// writeStatusAndBodyStmts returns the statements writing the status code of the
+// response, then its body with the method writeFunc.
+func writeStatusAndBodyStmts(writeFunc string, code string) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun:  Sel(I("w"), "WriteHeader"),
+				Args: []ast.Expr{Sel(I("response"), "StatusCode")},
+			},
+		},
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: Sel(I("h"), writeFunc),
+				Args: []ast.Expr{
+					I("w"),
+					I("r"),
+					Sel(I("response"), "Response"+code),
+				},
+			},
+		},
+		&ast.ReturnStmt{},
+	}
+}
+
 func (g *Generator) getContentTypeHeadeValue(contentType string) string {
 	textualContentType := map[string]struct{}{
 		"text/plain":             {},
+		"text/csv":               {},
 		"text/html":              {},
 		"text/css":               {},
 		"application/javascript": {},
@@ -789,46 +1031,77 @@ func (g *Generator) AddWriteHeadersForResponseCode(baseName string, code string,
 	return nil
 }
 
+// AddWriteResponseCode adds write<Op><code>Response writing the body of the
+// response, or one write<Op><code><Variant>Response per content type when the
+// response is negotiated.
 func (g *Generator) AddWriteResponseCode(baseName string, code string, response *openapi3.ResponseRef) error {
-	var body []ast.Stmt
+	contentTypes := sortedKeys(response.Value.Content)
+	if len(contentTypes) <= 1 {
+		var body []ast.Stmt
+		if len(contentTypes) == 1 {
+			body = g.writeResponseBodyStmts(contentTypes[0], response.Value.Content[contentTypes[0]], Sel(I("resp"), "Body"))
+		}
+		g.addWriteResponseCodeFunc("write"+baseName+code+"Response", baseName+"Response"+code, body)
 
-	if len(response.Value.Content) > 1 {
-		return errors.New("multiple responses are not supported")
+		return nil
 	}
-	for key, value := range response.Value.Content {
-		if key != applicationJSONCT {
-			return errors.New("only application/json content type is supported")
+	for _, contentType := range contentTypes {
+		field := Star(Sel(I("resp"), responseBodyField(contentType, contentTypes)))
+		g.addWriteResponseCodeFunc("write"+baseName+code+bodyVariant(contentType)+"Response", baseName+"Response"+code,
+			g.writeResponseBodyStmts(contentType, response.Value.Content[contentType], field),
+		)
+	}
+
+	return nil
+}
+
+// writeResponseBodyStmts returns the statements writing field, the body of the
+// media type: JSON types are encoded, binary bodies are streamed and the others
+// are written as is.
+func (g *Generator) writeResponseBodyStmts(contentType string, content *openapi3.MediaType, field ast.Expr) []ast.Stmt {
+	if content.Schema == nil {
+		return nil
+	}
+	var write ast.Stmt
+	if isBinaryContent(contentType, content) {
+		write = &ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  I("writeBinaryBody"),
+				Args: []ast.Expr{I("w"), addressOf(field)},
+			}},
 		}
-		if value.Schema != nil {
-			g.AddHandlersImport("encoding/json")
-			body = append(body, &ast.AssignStmt{
-				Lhs: []ast.Expr{I("err")},
-				Tok: token.ASSIGN,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: Sel(&ast.CallExpr{
-							Fun:  Sel(I("json"), "NewEncoder"),
-							Args: []ast.Expr{I("w")},
-						}, "Encode"),
+	} else if isJSONMediaType(contentType) {
+		g.AddHandlersImport("encoding/json")
+		write = &ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: Sel(&ast.CallExpr{
+						Fun:  Sel(I("json"), "NewEncoder"),
+						Args: []ast.Expr{I("w")},
+					}, "Encode"),
 
-						Args: []ast.Expr{Sel(I("resp"), "Body")},
-					},
-				},
-			})
-			body = append(body, &ast.IfStmt{
-				Cond: Ne(I("err"), I("nil")),
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						writeStandardErrorCall("StatusInternalServerError", Str("Internal Server Error")),
-						Ret(),
-					},
+					Args: []ast.Expr{field},
 				},
-			})
+			},
+		}
+	} else {
+		g.AddHandlersImport("io")
+		write = &ast.AssignStmt{
+			Lhs: []ast.Expr{I("_"), I("err")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("io"), "WriteString"),
+				Args: []ast.Expr{I("w"), &ast.CallExpr{Fun: I("string"), Args: []ast.Expr{field}}},
+			}},
 		}
 	}
 
-	if len(body) > 0 {
-		body = append([]ast.Stmt{&ast.DeclStmt{
+	return []ast.Stmt{
+		&ast.DeclStmt{
 			Decl: &ast.GenDecl{
 				Tok: token.VAR,
 				Specs: []ast.Spec{
@@ -838,24 +1111,34 @@ func (g *Generator) AddWriteResponseCode(baseName string, code string, response
 					},
 				},
 			},
-		}}, body...)
+		},
+		write,
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					writeStandardErrorCall("StatusInternalServerError", Str("Internal Server Error")),
+					Ret(),
+				},
+			},
+		},
 	}
+}
 
+func (g *Generator) addWriteResponseCodeFunc(name string, modelName string, body []ast.Stmt) {
 	writeResponseFunc := Func(
-		"write"+baseName+code+"Response",
+		name,
 		Field("h", Star(I("Handler")), ""),
 		[]*ast.Field{
 			Field("w", Sel(I("http"), "ResponseWriter"), ""),
 			Field("r", Star(Sel(I("http"), "Request")), ""),
-			Field("resp", Star(Sel(I(g.GetCurrentModelsPackage()), baseName+"Response"+code)), ""),
+			Field("resp", Star(Sel(I(g.GetCurrentModelsPackage()), modelName)), ""),
 		},
 		nil,
 		body,
 	)
 
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, writeResponseFunc)
-
-	return nil
 }
 
 func (g *Generator) AddParsePathParamsMethod(baseName string, params openapi3.Parameters) error {
@@ -871,6 +1154,7 @@ func (g *Generator) AddParsePathParamsMethod(baseName string, params openapi3.Pa
 				},
 			},
 		},
+		g.errsDecl(),
 	}
 
 	for _, param := range params {
@@ -879,59 +1163,29 @@ func (g *Generator) AddParsePathParamsMethod(baseName string, params openapi3.Pa
 		}
 
 		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
-		bodyList = append(bodyList, &ast.AssignStmt{
+		paramList := []ast.Stmt{}
+		paramList = append(paramList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName)},
 			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun:  Sel(I("chi"), "URLParam"),
-					Args: []ast.Expr{I("r"), Str(param.Value.Name)},
-				},
-			},
+			Rhs: []ast.Expr{g.pathValueExpr(param.Value.Name)},
 		})
-		bodyList = append(bodyList, &ast.IfStmt{
+		paramList = append(paramList, &ast.IfStmt{
 			Cond: Eq(I(varName), Str("")),
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{Ret2(I("nil"),
-					&ast.CallExpr{
-						Fun:  Sel(I("errors"), "New"),
-						Args: []ast.Expr{Str(param.Value.Name + " path param is required")},
-					},
+					requiredParamError(param.Value.Name+" path param is required"),
 				)},
 			},
 		})
-		g.AddHandlersImport("github.com/go-faster/errors")
-		switch {
-		case param.Value.Schema.Value.Type.Permits("string"):
-			bodyList = append(bodyList, &ast.AssignStmt{
-				Lhs: []ast.Expr{Sel(I("pathParams"), FormatGoLikeIdentifier(param.Value.Name))},
-				Tok: token.ASSIGN,
-				Rhs: []ast.Expr{
-					I(varName),
-				},
-			})
-		default:
-			return errors.New(fmt.Sprintf("unsupported path parameter type: %v", param.Value.Schema.Value.Type)) //nolint:revive
+		stmts, err := g.assignParamField("pathParams", varName, FormatGoLikeIdentifier(param.Value.Name),
+			param.Value.Schema, param.Value.Required)
+		if err != nil {
+			return errors.Wrap(err, "path parameter "+param.Value.Name)
 		}
+		paramList = append(paramList, stmts...)
+		bodyList = append(bodyList, collectParamErrors(param.Value.Name, paramList)...)
 	}
-
-	bodyList = append(bodyList, &ast.AssignStmt{
-		Lhs: []ast.Expr{I("err")},
-		Tok: token.DEFINE,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{
-					I("pathParams"),
-				},
-			},
-		},
-	})
-	bodyList = append(bodyList, &ast.IfStmt{
-		Cond: Ne(I("err"), I("nil")),
-		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-	})
-	bodyList = append(bodyList, Ret2(Amp(I("pathParams")), I("nil")))
+	bodyList = append(bodyList, collectedParamsEnd("pathParams")...)
 
 	parsePathParamsFunc := Func(
 		"parse"+baseName+"PathParams",
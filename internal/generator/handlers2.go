@@ -24,15 +24,32 @@ func (g *Generator) AddParseQueryParamsMethod(baseName string, params openapi3.P
 				},
 			},
 		},
+		g.errsDecl(),
 	}
-	errDefinedAtFuncScope := false
 	for _, param := range params {
 		if param.Value.Schema == nil || param.Value.Schema.Value == nil {
 			continue
 		}
+		if param.Value.Schema.Value.Type.Permits(openapi3.TypeArray) {
+			stmts, err := g.parseArrayQueryParam(param.Value)
+			if err != nil {
+				return err
+			}
+			bodyList = append(bodyList, collectParamErrors(param.Value.Name, stmts)...)
+			continue
+		}
+		if param.Value.Schema.Value.Type.Permits(openapi3.TypeObject) {
+			stmts, err := g.parseObjectQueryParam(baseName, param.Value)
+			if err != nil {
+				return err
+			}
+			bodyList = append(bodyList, collectParamErrors(param.Value.Name, stmts)...)
+			continue
+		}
 
 		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
-		bodyList = append(bodyList, &ast.AssignStmt{
+		paramList := []ast.Stmt{}
+		paramList = append(paramList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName)},
 			Tok: token.DEFINE,
 			Rhs: []ast.Expr{
@@ -46,61 +63,32 @@ func (g *Generator) AddParseQueryParamsMethod(baseName string, params openapi3.P
 			},
 		})
 		if param.Value.Required {
-			bodyList = append(bodyList, &ast.IfStmt{
+			paramList = append(paramList, &ast.IfStmt{
 				Cond: Eq(I(varName), Str("")),
 				Body: &ast.BlockStmt{
 					List: []ast.Stmt{Ret2(I("nil"),
-						&ast.CallExpr{
-							Fun: Sel(I("errors"), "New"),
-							Args: []ast.Expr{
-								Str(param.Value.Name + " query param is required"),
-							},
-						},
+						requiredParamError(param.Value.Name+" query param is required"),
 					)},
 				},
 			})
-			g.AddHandlersImport("github.com/go-faster/errors")
-			stmts, definesErr, err := g.assignParamField("queryParams", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, true)
+			stmts, err := g.assignParamField("queryParams", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, true)
 			if err != nil {
 				return err
 			}
-			bodyList = append(bodyList, stmts...)
-			if definesErr {
-				errDefinedAtFuncScope = true
-			}
+			paramList = append(paramList, stmts...)
 		} else {
-			stmts, _, err := g.assignParamField("queryParams", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, false)
+			stmts, err := g.assignParamField("queryParams", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, false)
 			if err != nil {
 				return err
 			}
-			bodyList = append(bodyList, &ast.IfStmt{
+			paramList = append(paramList, &ast.IfStmt{
 				Cond: Ne(I(varName), Str("")),
 				Body: &ast.BlockStmt{List: stmts},
 			})
 		}
+		bodyList = append(bodyList, collectParamErrors(param.Value.Name, paramList)...)
 	}
-	validatorTok := token.DEFINE
-	if errDefinedAtFuncScope {
-		validatorTok = token.ASSIGN
-	}
-	bodyList = append(bodyList, &ast.AssignStmt{
-		Lhs: []ast.Expr{I("err")},
-		Tok: validatorTok,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{
-					I("queryParams"),
-				},
-			},
-		},
-	})
-	bodyList = append(bodyList, &ast.IfStmt{
-		Cond: Ne(I("err"), I("nil")),
-		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-	})
-
-	bodyList = append(bodyList, Ret2(Amp(I("queryParams")), I("nil")))
+	bodyList = append(bodyList, collectedParamsEnd("queryParams")...)
 
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("parse"+baseName+"QueryParams",
 		Field("h", Star(I("Handler")), ""),
@@ -117,9 +105,42 @@ func (g *Generator) AddParseQueryParamsMethod(baseName string, params openapi3.P
 	return nil
 }
 
-func (g *Generator) AssignStringField(paramsName string, varName string, fieldName string, param *openapi3.SchemaRef, required bool) []ast.Stmt {
-	if param.Value.Format == "date-time" {
+// stringParseCall returns the call that converts a raw string parameter of the
+// given format into its typed value, or nil when the format is kept as string.
+func (g *Generator) stringParseCall(format string, varName string) (ast.Expr, string) {
+	switch format {
+	case "date-time":
 		g.AddHandlersImport("time")
+		return &ast.CallExpr{
+			Fun:  Sel(I("time"), "Parse"),
+			Args: []ast.Expr{Sel(I("time"), "RFC3339"), I(varName)},
+		}, "is not a valid date-time format"
+	case "date":
+		g.AddHandlersImport("time")
+		return &ast.CallExpr{
+			Fun:  Sel(I("time"), "Parse"),
+			Args: []ast.Expr{Sel(I("time"), "DateOnly"), I(varName)},
+		}, "is not a valid date format"
+	case "uuid":
+		g.AddHandlersImport("github.com/google/uuid")
+		return &ast.CallExpr{
+			Fun:  Sel(I("uuid"), "Parse"),
+			Args: []ast.Expr{I(varName)},
+		}, "is not a valid uuid"
+	case "decimal":
+		g.AddHandlersImport("github.com/shopspring/decimal")
+		return &ast.CallExpr{
+			Fun:  Sel(I("decimal"), "NewFromString"),
+			Args: []ast.Expr{I(varName)},
+		}, "is not a valid decimal"
+	}
+
+	return nil, ""
+}
+
+func (g *Generator) AssignStringField(paramsName string, varName string, fieldName string, param *openapi3.SchemaRef, required bool) []ast.Stmt {
+	if parseCall, errMsg := g.stringParseCall(param.Value.Format, varName); parseCall != nil {
+		g.AddHandlersImport("github.com/go-faster/errors")
 		var result []ast.Stmt
 		result = append(result, &ast.AssignStmt{
 			Lhs: []ast.Expr{
@@ -127,15 +148,7 @@ func (g *Generator) AssignStringField(paramsName string, varName string, fieldNa
 				I("err"),
 			},
 			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: Sel(I("time"), "Parse"),
-					Args: []ast.Expr{
-						Sel(I("time"), "RFC3339"),
-						I(varName),
-					},
-				},
-			},
+			Rhs: []ast.Expr{parseCall},
 		})
 		result = append(result, &ast.IfStmt{
 			Cond: Ne(I("err"), I("nil")),
@@ -146,7 +159,7 @@ func (g *Generator) AssignStringField(paramsName string, varName string, fieldNa
 						Fun: Sel(I("errors"), "Wrap"),
 						Args: []ast.Expr{
 							I("err"),
-							Str(fieldName + " is not a valid date-time format"),
+							Str(fieldName + " " + errMsg),
 						},
 					},
 				)},
@@ -165,6 +178,12 @@ func (g *Generator) AssignStringField(paramsName string, varName string, fieldNa
 			Rhs: []ast.Expr{rhs},
 		})
 	}
+
+	return []ast.Stmt{g.assignRawField(paramsName, varName, fieldName, required)}
+}
+
+// assignRawField stores the unparsed string variable varName in paramsName.fieldName.
+func (g *Generator) assignRawField(paramsName, varName, fieldName string, required bool) ast.Stmt {
 	var rhs ast.Expr
 	if required && !g.HandlersFile.requiredFieldsArePointers {
 		rhs = I(varName)
@@ -172,23 +191,61 @@ func (g *Generator) AssignStringField(paramsName string, varName string, fieldNa
 		rhs = Amp(I(varName))
 	}
 
-	return []ast.Stmt{&ast.AssignStmt{
+	return &ast.AssignStmt{
 		Lhs: []ast.Expr{Sel(I(paramsName), fieldName)},
 		Tok: token.ASSIGN,
 		Rhs: []ast.Expr{rhs},
-	}}
+	}
 }
 
-func (g *Generator) assignParamField(paramsName, varName, fieldName string, schema *openapi3.SchemaRef, required bool) ([]ast.Stmt, bool, error) {
+func (g *Generator) assignParamField(paramsName, varName, fieldName string, schema *openapi3.SchemaRef, required bool) ([]ast.Stmt, error) {
 	switch {
 	case schema.Value.Type.Permits(openapi3.TypeString):
-		return g.AssignStringField(paramsName, varName, fieldName, schema, required),
-			schema.Value.Format == "date-time", nil
+		return g.AssignStringField(paramsName, varName, fieldName, schema, required), nil
 	case schema.Value.Type.Permits(openapi3.TypeInteger), schema.Value.Type.Permits(openapi3.TypeNumber):
-		return g.AssignNumericField(paramsName, varName, fieldName, schema, required), true, nil
+		return g.AssignNumericField(paramsName, varName, fieldName, schema, required), nil
+	case schema.Value.Type.Permits(openapi3.TypeBoolean):
+		return g.AssignBoolField(paramsName, varName, fieldName, required), nil
 	default:
-		return nil, false, errors.New("unsupported parameter type: " + fmt.Sprint(schema.Value.Type))
+		return nil, errors.New("unsupported parameter type: " + fmt.Sprint(schema.Value.Type))
+	}
+}
+
+func (g *Generator) AssignBoolField(paramsName, varName, fieldName string, required bool) []ast.Stmt {
+	g.AddHandlersImport("strconv")
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	result := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("parsed" + fieldName), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("strconv"), "ParseBool"),
+				Args: []ast.Expr{I(varName)},
+			}},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(
+				I("nil"),
+				&ast.CallExpr{
+					Fun:  Sel(I("errors"), "Wrap"),
+					Args: []ast.Expr{I("err"), Str(fieldName + " is not a valid boolean")},
+				},
+			)}},
+		},
+	}
+
+	var rhs ast.Expr = I("parsed" + fieldName)
+	if !required || g.HandlersFile.requiredFieldsArePointers {
+		rhs = Amp(rhs)
 	}
+
+	return append(result, &ast.AssignStmt{
+		Lhs: []ast.Expr{Sel(I(paramsName), fieldName)},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{rhs},
+	})
 }
 
 func (g *Generator) AssignNumericField(paramsName, varName, fieldName string, param *openapi3.SchemaRef, required bool) []ast.Stmt {
@@ -295,8 +352,8 @@ func (g *Generator) AddParseHeadersMethod(baseName string, params openapi3.Param
 				},
 			},
 		},
+		g.errsDecl(),
 	}
-	errDefinedAtFuncScope := false
 	for _, param := range params {
 		if param.Value.Schema == nil || param.Value.Schema.Value == nil {
 			continue
@@ -310,7 +367,8 @@ func (g *Generator) AddParseHeadersMethod(baseName string, params openapi3.Param
 			continue
 		}
 		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
-		bodyList = append(bodyList, &ast.AssignStmt{
+		paramList := []ast.Stmt{}
+		paramList = append(paramList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName)},
 			Tok: token.DEFINE,
 			Rhs: []ast.Expr{
@@ -321,60 +379,32 @@ func (g *Generator) AddParseHeadersMethod(baseName string, params openapi3.Param
 			},
 		})
 		if param.Value.Required {
-			bodyList = append(bodyList, &ast.IfStmt{
+			paramList = append(paramList, &ast.IfStmt{
 				Cond: Eq(I(varName), Str("")),
 				Body: &ast.BlockStmt{
 					List: []ast.Stmt{Ret2(I("nil"),
-						&ast.CallExpr{
-							Fun: Sel(I("errors"), "New"),
-							Args: []ast.Expr{
-								Str(param.Value.Name + " header is required"),
-							},
-						},
+						requiredParamError(param.Value.Name+" header is required"),
 					)},
 				},
 			})
-			g.AddHandlersImport("github.com/go-faster/errors")
-			stmts, definesErr, err := g.assignParamField("headers", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, true)
+			stmts, err := g.assignParamField("headers", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, true)
 			if err != nil {
 				return err
 			}
-			bodyList = append(bodyList, stmts...)
-			if definesErr {
-				errDefinedAtFuncScope = true
-			}
+			paramList = append(paramList, stmts...)
 		} else {
-			stmts, _, err := g.assignParamField("headers", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, false)
+			stmts, err := g.assignParamField("headers", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, false)
 			if err != nil {
 				return err
 			}
-			bodyList = append(bodyList, &ast.IfStmt{
+			paramList = append(paramList, &ast.IfStmt{
 				Cond: Ne(I(varName), Str("")),
 				Body: &ast.BlockStmt{List: stmts},
 			})
 		}
+		bodyList = append(bodyList, collectParamErrors(param.Value.Name, paramList)...)
 	}
-	validatorTok := token.DEFINE
-	if errDefinedAtFuncScope {
-		validatorTok = token.ASSIGN
-	}
-	bodyList = append(bodyList, &ast.AssignStmt{
-		Lhs: []ast.Expr{I("err")},
-		Tok: validatorTok,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{
-					I("headers"),
-				},
-			},
-		},
-	})
-	bodyList = append(bodyList, &ast.IfStmt{
-		Cond: Ne(I("err"), I("nil")),
-		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-	})
-	bodyList = append(bodyList, Ret2(Amp(I("headers")), I("nil")))
+	bodyList = append(bodyList, collectedParamsEnd("headers")...)
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("parse"+baseName+"Headers",
 		Field("h", Star(I("Handler")), ""),
 		[]*ast.Field{
@@ -403,6 +433,7 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 				},
 			},
 		},
+		g.errsDecl(),
 	}
 	for _, param := range params {
 		if param.Value.Schema == nil || param.Value.Schema.Value == nil {
@@ -410,7 +441,8 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 		}
 
 		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
-		bodyList = append(bodyList, &ast.AssignStmt{
+		paramList := []ast.Stmt{}
+		paramList = append(paramList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName), I("err")},
 			Tok: token.DEFINE,
 			Rhs: []ast.Expr{
@@ -422,12 +454,14 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 		})
 
 		if param.Value.Required {
-			bodyList = append(bodyList, &ast.IfStmt{
+			paramList = append(paramList, &ast.IfStmt{
 				Cond: Ne(I("err"), I("nil")),
-				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"),
+					requiredParamError(param.Value.Name+" cookie is required"),
+				)}},
 			})
 		} else {
-			bodyList = append(bodyList, &ast.IfStmt{
+			paramList = append(paramList, &ast.IfStmt{
 				Cond: &ast.BinaryExpr{
 					X:  Ne(I("err"), I("nil")),
 					Op: token.LAND,
@@ -448,7 +482,7 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 		}
 
 		if param.Value.Required {
-			bodyList = append(bodyList, &ast.AssignStmt{
+			paramList = append(paramList, &ast.AssignStmt{
 				Lhs: []ast.Expr{I(varName + "Value")},
 				Tok: token.DEFINE,
 				Rhs: []ast.Expr{Sel(I(varName), "Value")},
@@ -456,7 +490,7 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 
 			switch {
 			case param.Value.Schema.Value.Type.Permits("string"):
-				bodyList = append(bodyList,
+				paramList = append(paramList,
 					g.AssignStringField("cookies", varName+"Value", FormatGoLikeIdentifier(param.Value.Name),
 						param.Value.Schema, param.Value.Required,
 					)...,
@@ -475,29 +509,16 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 					param.Value.Schema, param.Value.Required,
 				)...,
 			)
-			bodyList = append(bodyList, &ast.IfStmt{
+			paramList = append(paramList, &ast.IfStmt{
 				Cond: Eq(I("err"), I("nil")),
 				Body: &ast.BlockStmt{
 					List: ifBody,
 				},
 			})
 		}
+		bodyList = append(bodyList, collectParamErrors(param.Value.Name, paramList)...)
 	}
-	bodyList = append(bodyList, &ast.AssignStmt{
-		Lhs: []ast.Expr{I("err")},
-		Tok: token.ASSIGN,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun:  Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{I("cookies")},
-			},
-		},
-	})
-	bodyList = append(bodyList, &ast.IfStmt{
-		Cond: Ne(I("err"), I("nil")),
-		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-	})
-	bodyList = append(bodyList, Ret2(Amp(I("cookies")), I("nil")))
+	bodyList = append(bodyList, collectedParamsEnd("cookies")...)
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("parse"+baseName+"Cookies",
 		Field("h", Star(I("Handler")), ""),
 		[]*ast.Field{
@@ -514,31 +535,69 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 }
 
 func (g *Generator) GetValidateFuncStmt(typeName string, ref string) ast.Expr {
+	validateFunc, importPath := g.validateFuncRef(typeName, ref)
+	if importPath != "" {
+		g.AddHandlersImport(importPath)
+	}
+
+	return validateFunc
+}
+
+// validateFuncRef returns the Validate<Model>JSON function of the schema and
+// the import path of the package declaring it, empty for the current one.
+func (g *Generator) validateFuncRef(typeName string, ref string) (ast.Expr, string) {
 	validateFuncName := "Validate" + typeName + "JSON"
 
 	if ref == "" || !refIsExternal(ref) {
-		return I(validateFuncName)
+		return I(validateFuncName), ""
 	}
 
 	filename := parseFilenameFromRef(ref)
 	if filename == "" {
-		return I(validateFuncName)
+		return I(validateFuncName), ""
 	}
 
 	parts := strings.Split(ref, "/")
 	if len(parts) == 0 {
-		return I(validateFuncName)
+		return I(validateFuncName), ""
 	}
 
 	validateFuncName = "Validate" + parts[len(parts)-1] + "JSON"
 
 	g.YAMLFilesToProcess = append(g.YAMLFilesToProcess, g.GetYAMLFilePath(filename))
-	g.AddHandlersImport(g.GetHandlersImportForFile(filename))
 	modelName := g.GetModelName(filename)
-	return Sel(I(modelName), validateFuncName)
+	return Sel(I(modelName), validateFuncName), g.GetHandlersImportForFile(filename)
+}
+
+// requestBodyType returns the name of the model of the body content and its
+// type in the handlers file.
+func (g *Generator) requestBodyType(baseName string, content *openapi3.MediaType) (string, ast.Expr) {
+	typeName := baseName + "RequestBody"
+	if content == nil || content.Schema == nil || content.Schema.Ref == "" {
+		return typeName, Sel(I(g.GetCurrentModelsPackage()), typeName)
+	}
+
+	typeName, importPath := g.ParseRefTypeName(content.Schema.Ref)
+	if importPath != "" {
+		g.AddHandlersImport(importPath)
+	}
+	if refIsExternal(content.Schema.Ref) {
+		return typeName, I(typeName)
+	}
+
+	return typeName, Sel(I(g.GetCurrentModelsPackage()), typeName)
 }
 
 func (g *Generator) AddParseRequestBodyMethod(baseName string, contentType string, body *openapi3.RequestBodyRef) error {
+	content, ok := body.Value.Content[contentType]
+	if contentType == applicationFormCT || contentType == multipartFormCT {
+		return g.AddParseFormRequestBodyMethod(baseName, contentType, content, body.Value.Required)
+	}
+	if isBinaryContent(contentType, content) {
+		g.AddParseBinaryRequestBodyMethod(baseName, body.Value.Required)
+		return nil
+	}
+
 	bodyList := []ast.Stmt{}
 	if !body.Value.Required {
 		bodyList = append(bodyList, &ast.IfStmt{
@@ -547,23 +606,7 @@ func (g *Generator) AddParseRequestBodyMethod(baseName string, contentType strin
 		})
 	}
 
-	typeName := baseName + "RequestBody"
-	var bodyType ast.Expr
-	content, ok := body.Value.Content[contentType]
-	bodyType = Sel(I(g.GetCurrentModelsPackage()), typeName)
-	if ok && content.Schema != nil {
-		if content.Schema.Ref != "" {
-			var importPath string
-			typeName, importPath = g.ParseRefTypeName(content.Schema.Ref)
-			bodyType = Sel(I(g.GetCurrentModelsPackage()), typeName)
-			if importPath != "" {
-				g.AddHandlersImport(importPath)
-			}
-			if refIsExternal(content.Schema.Ref) {
-				bodyType = I(typeName)
-			}
-		}
-	}
+	typeName, bodyType := g.requestBodyType(baseName, content)
 	bodyList = append(bodyList, &ast.DeclStmt{
 		Decl: &ast.GenDecl{
 			Tok: token.VAR,
@@ -642,17 +685,21 @@ func (g *Generator) AddParseRequestBodyMethod(baseName string, contentType strin
 		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
 	})
 
+	validateCall := &ast.CallExpr{
+		Fun:  Sel(Sel(I("h"), "validator"), "Struct"),
+		Args: []ast.Expr{I("body")},
+	}
+	if ok && content.Schema != nil && isMapSchema(content.Schema.Value) {
+		// validator.Struct rejects maps, their values are checked with dive
+		validateCall = &ast.CallExpr{
+			Fun:  Sel(Sel(I("h"), "validator"), "Var"),
+			Args: []ast.Expr{I("body"), Str(strings.Join(GetSchemaValidators(content.Schema), ","))},
+		}
+	}
 	bodyList = append(bodyList, &ast.AssignStmt{
 		Lhs: []ast.Expr{I("err")},
 		Tok: token.ASSIGN,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{
-					I("body"),
-				},
-			},
-		},
+		Rhs: []ast.Expr{validateCall},
 	})
 	bodyList = append(bodyList, &ast.IfStmt{
 		Cond: Ne(I("err"), I("nil")),
@@ -676,7 +723,17 @@ func (g *Generator) AddParseRequestBodyMethod(baseName string, contentType strin
 	return nil
 }
 
-func (g *Generator) AddParseRequestMethod(baseName string, contentType string, pathParams openapi3.Parameters,
+// requestBodyArgs returns the arguments of the parser of a request body of the
+// content type: a multipart body also takes the files opened for the request.
+func requestBodyArgs(contentType string) []ast.Expr {
+	if contentType == multipartFormCT {
+		return []ast.Expr{I("r"), I("opened")}
+	}
+
+	return []ast.Expr{I("r")}
+}
+
+func (g *Generator) AddParseRequestMethod(baseName string, contentTypes []string, pathParams openapi3.Parameters,
 	queryParams openapi3.Parameters, headers openapi3.Parameters, cookieParams openapi3.Parameters,
 	body *openapi3.RequestBodyRef,
 ) {
@@ -702,10 +759,10 @@ func (g *Generator) AddParseRequestMethod(baseName string, contentType string, p
 				},
 			},
 		})
-		bodyList = append(bodyList, &ast.IfStmt{
-			Cond: Ne(I("err"), I("nil")),
-			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-		})
+		bodyList = append(bodyList, &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(I("errs"), "merge"),
+			Args: []ast.Expr{Str("path"), I("err")},
+		}})
 	}
 	if len(queryParams) > 0 {
 		elts = append(elts, &ast.KeyValueExpr{
@@ -727,10 +784,10 @@ func (g *Generator) AddParseRequestMethod(baseName string, contentType string, p
 				},
 			},
 		})
-		bodyList = append(bodyList, &ast.IfStmt{
-			Cond: Ne(I("err"), I("nil")),
-			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-		})
+		bodyList = append(bodyList, &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(I("errs"), "merge"),
+			Args: []ast.Expr{Str("query"), I("err")},
+		}})
 	}
 	if len(headers) > 0 {
 		elts = append(elts, &ast.KeyValueExpr{
@@ -752,10 +809,10 @@ func (g *Generator) AddParseRequestMethod(baseName string, contentType string, p
 				},
 			},
 		})
-		bodyList = append(bodyList, &ast.IfStmt{
-			Cond: Ne(I("err"), I("nil")),
-			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-		})
+		bodyList = append(bodyList, &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(I("errs"), "merge"),
+			Args: []ast.Expr{Str("header"), I("err")},
+		}})
 	}
 	if len(cookieParams) > 0 {
 		elts = append(elts, &ast.KeyValueExpr{
@@ -777,14 +834,71 @@ func (g *Generator) AddParseRequestMethod(baseName string, contentType string, p
 				},
 			},
 		})
-		bodyList = append(bodyList, &ast.IfStmt{
-			Cond: Ne(I("err"), I("nil")),
-			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-		})
+		bodyList = append(bodyList, &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(I("errs"), "merge"),
+			Args: []ast.Expr{Str("cookie"), I("err")},
+		}})
 	}
-	if body != nil && body.Value != nil {
-		content, ok := body.Value.Content[contentType]
-		if ok && content.Schema != nil {
+	params := []*ast.Field{
+		Field("r", Star(Sel(I("http"), "Request")), ""),
+	}
+	if len(contentTypes) > 1 {
+		// the body is read by the parser of the negotiated content type
+		params = append(params, Field("contentType", I("string"), ""))
+		elts = append(elts, &ast.KeyValueExpr{Key: I("ContentType"), Value: I("contentType")})
+		switchBody := &ast.BlockStmt{}
+		for _, contentType := range contentTypes {
+			content := body.Value.Content[contentType]
+			if content.Schema == nil {
+				continue
+			}
+			bodyName := requestBodyName(baseName, contentType, contentTypes)
+			varName := GoIdentLowercase(bodyVariant(contentType)) + "Body"
+			_, bodyType := g.requestBodyType(bodyName, content)
+			if isBinaryContent(contentType, content) {
+				bodyType = g.binaryBodyType()
+			}
+			bodyList = append(bodyList, &ast.DeclStmt{
+				Decl: &ast.GenDecl{
+					Tok: token.VAR,
+					Specs: []ast.Spec{
+						&ast.ValueSpec{
+							Names: []*ast.Ident{I(varName)},
+							Type:  Star(bodyType),
+						},
+					},
+				},
+			})
+			elts = append(elts, &ast.KeyValueExpr{Key: I(bodyVariant(contentType) + "Body"), Value: I(varName)})
+			switchBody.List = append(switchBody.List, &ast.CaseClause{
+				List: []ast.Expr{Str(contentType)},
+				Body: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I("body"), I("err")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{&ast.CallExpr{
+							Fun:  Sel(I("h"), "parse"+bodyName+"RequestBody"),
+							Args: requestBodyArgs(contentType),
+						}},
+					},
+					&ast.ExprStmt{X: &ast.CallExpr{
+						Fun:  Sel(I("errs"), "merge"),
+						Args: []ast.Expr{Str("body"), I("err")},
+					}},
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I(varName)},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{I("body")},
+					},
+				},
+			})
+		}
+		if len(switchBody.List) > 0 {
+			bodyList = append(bodyList, &ast.SwitchStmt{Tag: I("contentType"), Body: switchBody})
+		}
+	} else if len(contentTypes) == 1 {
+		content := body.Value.Content[contentTypes[0]]
+		if content.Schema != nil {
 			if body.Value.Required {
 				elts = append(elts, &ast.KeyValueExpr{
 					Key:   I("Body"),
@@ -804,20 +918,33 @@ func (g *Generator) AddParseRequestMethod(baseName string, contentType string, p
 				Tok: token.DEFINE,
 				Rhs: []ast.Expr{
 					&ast.CallExpr{
-						Fun: Sel(I("h"), "parse"+baseName+"RequestBody"),
-						Args: []ast.Expr{
-							I("r"),
-						},
+						Fun:  Sel(I("h"), "parse"+baseName+"RequestBody"),
+						Args: requestBodyArgs(contentTypes[0]),
 					},
 				},
 			})
-			bodyList = append(bodyList, &ast.IfStmt{
-				Cond: Ne(I("err"), I("nil")),
-				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
-			})
+			bodyList = append(bodyList, &ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  Sel(I("errs"), "merge"),
+				Args: []ast.Expr{Str("body"), I("err")},
+			}})
 		}
 	}
 
+	if g.HandlersFile.multipart[baseName] {
+		// handle<Op>Request closes the files the body parser opens
+		params = append(params, Field("opened", Star(I("multipartFiles")), ""))
+	}
+	if len(bodyList) > 0 {
+		bodyList = append([]ast.Stmt{g.errsDecl()}, bodyList...)
+		bodyList = append(bodyList, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{Sel(I("errs"), "Errors")}},
+				Op: token.GTR,
+				Y:  intLit("0"),
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), Amp(I("errs")))}},
+		})
+	}
 	bodyList = append(bodyList,
 		Ret2(Amp(&ast.CompositeLit{
 			Type: Sel(I(g.GetCurrentModelsPackage()), baseName+"Request"),
@@ -830,9 +957,7 @@ func (g *Generator) AddParseRequestMethod(baseName string, contentType string, p
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func(
 		"parse"+baseName+"Request",
 		Field("h", Star(I("Handler")), ""),
-		[]*ast.Field{
-			Field("r", Star(Sel(I("http"), "Request")), ""),
-		},
+		params,
 		[]*ast.Field{
 			Field("", Star(Sel(I(g.GetCurrentModelsPackage()), baseName+"Request")), ""),
 			Field("", I("error"), ""),
@@ -845,38 +970,45 @@ func (g *Generator) AddCreateResponseModel(baseName string, code string, respons
 	arglist := []*ast.Field{}
 	constructorArgs := []ast.Expr{}
 
-	if len(response.Value.Content) > 0 {
-		// assume there is a json body
-		json, ok := response.Value.Content["application/json"]
-		if !ok {
-			return errors.New("response content type 'application/json' not found")
+	contentTypes := sortedKeys(response.Value.Content)
+	for _, contentType := range contentTypes {
+		content := response.Value.Content[contentType]
+		if content.Schema == nil {
+			continue
 		}
-		if json.Schema != nil {
-			typeName := baseName + "Response" + code + "Body"
-			var astType ast.Expr
-			astType = Sel(I(g.GetCurrentModelsPackage()), typeName)
-			if json.Schema.Ref != "" {
-				schemaRef := resolveSchemaRefAgainstResponse(response.Ref, json.Schema.Ref)
-				var importPath string
-				typeName, importPath = g.ParseRefTypeName(schemaRef)
-				if refIsExternal(schemaRef) {
-					astType = I(typeName)
-				} else {
-					astType = Sel(I(g.GetCurrentModelsPackage()), typeName)
-				}
-				if importPath != "" {
-					g.AddHandlersImport(importPath)
-				}
+		fieldName := responseBodyField(contentType, contentTypes)
+		typeName := baseName + "Response" + code + fieldName
+		var astType ast.Expr
+		astType = Sel(I(g.GetCurrentModelsPackage()), typeName)
+		if isBinaryContent(contentType, content) {
+			astType = g.binaryBodyType()
+		} else if content.Schema.Ref != "" {
+			schemaRef := resolveSchemaRefAgainstResponse(response.Ref, content.Schema.Ref)
+			var importPath string
+			typeName, importPath = g.ParseRefTypeName(schemaRef)
+			if refIsExternal(schemaRef) {
+				astType = I(typeName)
+			} else {
+				astType = Sel(I(g.GetCurrentModelsPackage()), typeName)
+			}
+			if importPath != "" {
+				g.AddHandlersImport(importPath)
 			}
-			arglist = append(arglist, &ast.Field{
-				Names: []*ast.Ident{I("body")},
-				Type:  astType,
-			})
-			constructorArgs = append(constructorArgs, &ast.KeyValueExpr{
-				Key:   I("Body"),
-				Value: I("body"),
-			})
 		}
+		// the constructor of a negotiated response offers the bodies that are
+		// not nil
+		argName := GoIdentLowercase(fieldName)
+		if len(contentTypes) > 1 {
+			astType = Star(astType)
+		}
+		arglist = append(arglist, &ast.Field{
+			Names: []*ast.Ident{I(argName)},
+			Type:  astType,
+		})
+		constructorArgs = append(constructorArgs, &ast.KeyValueExpr{
+			Key:   I(fieldName),
+			Value: I(argName),
+		})
 	}
 
 	if len(response.Value.Headers) > 0 {
@@ -1007,19 +1139,27 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 			}
 			objectFields[fieldName] = g.GetValidateFuncStmt(fieldType, fieldSchema.Ref)
 		}
-		if fieldSchema.Value.Type.Permits(openapi3.TypeArray) {
-			if fieldSchema.Value.Items != nil {
-				itemsType := g.getMostNestedArrayItemType(fieldSchema.Value.Items)
-				if itemsType != nil && itemsType.Permits(openapi3.TypeObject) {
-					fieldType, err := g.GetFieldTypeFromSchema(modelName, fieldName, fieldSchema)
-					if err != nil {
-						return errors.Wrap(err, op)
-					}
-					objectFields[fieldName] = g.GetValidateFuncStmt(fieldType, fieldSchema.Ref)
-				}
+		if fieldSchema.Value.Type.Permits(openapi3.TypeArray) && g.hasValidateFunc(fieldSchema.Value) {
+			fieldType, err := g.GetFieldTypeFromSchema(modelName, fieldName, fieldSchema)
+			if err != nil {
+				return errors.Wrap(err, op)
 			}
+			objectFields[fieldName] = g.GetValidateFuncStmt(fieldType, fieldSchema.Ref)
+		}
+	}
+	additional := schema.Value.AdditionalProperties
+	closed := g.rejectsUnknownFields(schema.Value)
+	var additionalValidate ast.Expr
+	if additional.Schema != nil && !hasUntypedAdditionalProperties(schema.Value) &&
+		g.hasValidateFunc(additional.Schema.Value) {
+		valueType, err := g.GetFieldTypeFromSchema(modelName, "Value", additional.Schema)
+		if err != nil {
+			return errors.Wrap(err, op)
 		}
+		additionalValidate = g.GetValidateFuncStmt(valueType, additional.Schema.Ref)
 	}
+	checksKeys := closed || additionalValidate != nil
+
 	requiredFields := make([]string, 0, len(requiredFieldsMap))
 	for fieldName := range requiredFieldsMap {
 		requiredFields = append(requiredFields, fieldName)
@@ -1032,20 +1172,14 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 	if len(requiredFields) > 0 {
 		requiredFieldsElts := make([]ast.Expr, 0, len(requiredFields))
 		for _, fieldName := range requiredFields {
-			requiredFieldsElts = append(requiredFieldsElts, &ast.KeyValueExpr{
-				Key:   Str(fieldName),
-				Value: I("true"),
-			})
+			requiredFieldsElts = append(requiredFieldsElts, Str(fieldName))
 		}
 		funcBody = append(funcBody, &ast.AssignStmt{
 			Lhs: []ast.Expr{I("requiredFields")},
 			Tok: token.DEFINE,
 			Rhs: []ast.Expr{
 				&ast.CompositeLit{
-					Type: &ast.MapType{
-						Key:   I("string"),
-						Value: I("bool"),
-					},
+					Type: &ast.ArrayType{Elt: I("string")},
 					Elts: requiredFieldsElts,
 				},
 			},
@@ -1073,7 +1207,7 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 		})
 	}
 
-	if len(requiredFields) > 0 || len(objectFields) > 0 {
+	if len(requiredFields) > 0 || len(objectFields) > 0 || checksKeys {
 		funcBody = append(funcBody, &ast.DeclStmt{
 			Decl: &ast.GenDecl{
 				Tok: token.VAR,
@@ -1106,6 +1240,7 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 				},
 			},
 		})
+		funcBody = append(funcBody, g.errsDecl())
 	}
 	if len(requiredFields) > 0 || len(objectFields) > 0 {
 		funcBody = append(funcBody, &ast.DeclStmt{
@@ -1133,9 +1268,10 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 	}
 	if len(requiredFields) > 0 {
 		funcBody = append(funcBody, &ast.RangeStmt{
-			Key: I("field"),
-			Tok: token.DEFINE,
-			X:   I("requiredFields"),
+			Key:   I("_"),
+			Value: I("field"),
+			Tok:   token.DEFINE,
+			X:     I("requiredFields"),
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
 					&ast.AssignStmt{
@@ -1155,20 +1291,16 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 						},
 						Body: &ast.BlockStmt{
 							List: []ast.Stmt{
-								Ret1(&ast.CallExpr{
-									Fun: Sel(I("errors"), "New"),
-									Args: []ast.Expr{
-										&ast.BinaryExpr{
-											X: &ast.BinaryExpr{
-												X:  Str("field "),
-												Op: token.ADD,
-												Y:  I("field"),
-											},
-											Op: token.ADD,
-											Y:  Str(" is required"),
-										},
+								addFieldError(I("field"), "required", &ast.BinaryExpr{
+									X: &ast.BinaryExpr{
+										X:  Str("field "),
+										Op: token.ADD,
+										Y:  I("field"),
 									},
+									Op: token.ADD,
+									Y:  Str(" is required"),
 								}),
+								&ast.BranchStmt{Tok: token.CONTINUE},
 							},
 						},
 					},
@@ -1189,19 +1321,14 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 						},
 						Body: &ast.BlockStmt{
 							List: []ast.Stmt{
-								Ret1(&ast.CallExpr{
-									Fun: Sel(I("errors"), "New"),
-									Args: []ast.Expr{
-										&ast.BinaryExpr{
-											X: &ast.BinaryExpr{
-												X:  Str("field "),
-												Op: token.ADD,
-												Y:  I("field"),
-											},
-											Op: token.ADD,
-											Y:  Str(" cannot be null"),
-										},
+								addFieldError(I("field"), "null", &ast.BinaryExpr{
+									X: &ast.BinaryExpr{
+										X:  Str("field "),
+										Op: token.ADD,
+										Y:  I("field"),
 									},
+									Op: token.ADD,
+									Y:  Str(" cannot be null"),
 								}),
 							},
 						},
@@ -1210,7 +1337,6 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 			},
 		})
 		g.AddContainsNullIfNeeded()
-		g.AddHandlersImport("github.com/go-faster/errors")
 	}
 
 	objectFieldsNames := make([]string, 0, len(objectFields))
@@ -1245,45 +1371,27 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 			},
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
-					&ast.AssignStmt{
-						Lhs: []ast.Expr{I("err")},
-						Tok: token.ASSIGN,
-						Rhs: []ast.Expr{
-							&ast.CallExpr{
-								Fun:  fieldValidationFunc,
-								Args: []ast.Expr{I("val")},
-							},
-						},
-					},
-					&ast.IfStmt{
-						Cond: Ne(I("err"), I("nil")),
-						Body: &ast.BlockStmt{
-							List: []ast.Stmt{
-								Ret1(&ast.CallExpr{
-									Fun: Sel(I("errors"), "Wrap"),
-									Args: []ast.Expr{
-										I("err"),
-										Str("field " + fieldName + " is not valid"),
-									},
-								}),
-							},
-						},
-					},
+					mergeFieldErrors(Str(fieldName), &ast.CallExpr{
+						Fun:  fieldValidationFunc,
+						Args: []ast.Expr{I("val")},
+					}),
 				},
 			},
 		})
 
 		g.AddContainsNullIfNeeded()
-		g.AddHandlersImport("github.com/go-faster/errors")
 	}
 
-	funcBody = append(funcBody, &ast.ReturnStmt{
-		Results: []ast.Expr{I("nil")},
-	})
+	if checksKeys {
+		funcBody = append(funcBody, g.additionalPropertiesValidateStmts(schema.Value, closed, additionalValidate)...)
+	}
 
 	fieldName := "jsonData"
-	if len(requiredFields) == 0 && len(objectFields) == 0 {
+	if len(requiredFields) == 0 && len(objectFields) == 0 && !checksKeys {
 		fieldName = "_"
+		funcBody = append(funcBody, Ret1(I("nil")))
+	} else {
+		funcBody = append(funcBody, Ret1(&ast.CallExpr{Fun: Sel(I("errs"), "Err")}))
 	}
 
 	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("Validate"+modelName+"JSON",
@@ -1346,6 +1454,7 @@ func (g *Generator) AddArrayValidate(modelName string, schema *openapi3.SchemaRe
 					List: []ast.Stmt{Ret1(I("err"))},
 				},
 			},
+			g.errsDecl(),
 			&ast.RangeStmt{
 				Key:   I("index"),
 				Value: I("obj"),
@@ -1363,41 +1472,23 @@ func (g *Generator) AddArrayValidate(modelName string, schema *openapi3.SchemaRe
 							},
 							Body: &ast.BlockStmt{
 								List: []ast.Stmt{
-									&ast.AssignStmt{
-										Lhs: []ast.Expr{I("err")},
-										Tok: token.ASSIGN,
-										Rhs: []ast.Expr{
-											&ast.CallExpr{
-												Fun:  validateFunc,
-												Args: []ast.Expr{I("obj")},
-											},
-										},
-									},
-									&ast.IfStmt{
-										Cond: Ne(I("err"), I("nil")),
-										Body: &ast.BlockStmt{
-											List: []ast.Stmt{Ret1(&ast.CallExpr{
-												Fun: Sel(I("errors"), "Wrapf"),
-												Args: []ast.Expr{
-													I("err"),
-													Str("error validating object at index %d"),
-													I("index"),
-												},
-											})},
-										},
-									},
+									mergeFieldErrors(&ast.CallExpr{
+										Fun:  Sel(I("strconv"), "Itoa"),
+										Args: []ast.Expr{I("index")},
+									}, &ast.CallExpr{
+										Fun:  validateFunc,
+										Args: []ast.Expr{I("obj")},
+									}),
 								},
 							},
 						},
 					},
 				},
 			},
-			&ast.ReturnStmt{
-				Results: []ast.Expr{I("nil")},
-			},
+			Ret1(&ast.CallExpr{Fun: Sel(I("errs"), "Err")}),
 		},
 	))
-	g.AddHandlersImport("github.com/go-faster/errors")
+	g.AddHandlersImport("strconv")
 
 	return nil
 }
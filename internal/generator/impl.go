@@ -0,0 +1,244 @@
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+// implOperation is an operation the implementation scaffold has a method for.
+type implOperation struct {
+	baseName string
+	// notImplemented is the code of the declared 501 response built by a
+	// constructor without arguments, empty when the stub returns ErrNotImplemented
+	notImplemented string
+}
+
+type ImplFile struct {
+	operations []implOperation
+}
+
+func (g *Generator) NewImplFile() {
+	g.ImplFile = &ImplFile{}
+}
+
+// AddImplMethod registers the operation whose handler method is scaffolded.
+func (g *Generator) AddImplMethod(baseName string, operation *openapi3.Operation) {
+	if g.Opts.ImplDir == "" {
+		return
+	}
+	implOp := implOperation{baseName: baseName}
+	const code = "501"
+	if response := operation.Responses.Value(code); response != nil && len(response.Value.Headers) == 0 {
+		implOp.notImplemented = code
+		for _, content := range response.Value.Content {
+			if content.Schema != nil {
+				implOp.notImplemented = ""
+			}
+		}
+	}
+	g.ImplFile.operations = append(g.ImplFile.operations, implOp)
+}
+
+// ImplPackage returns the name of the implementation package of the spec.
+func (g *Generator) ImplPackage() string {
+	return g.PackageName + "impl"
+}
+
+// WriteImplFile writes impl.go, the implementation scaffold, to the package
+// directory under -impl. An existing file is never rewritten: the methods of
+// operations it does not implement yet are appended to it, with the imports
+// they need.
+func (g *Generator) WriteImplFile() error {
+	const op = "generator.WriteImplFile"
+	if g.Opts.ImplDir == "" || len(g.ImplFile.operations) == 0 {
+		return nil
+	}
+
+	implPath := path.Join(g.Opts.ImplDir, g.ImplPackage())
+	err := os.MkdirAll(implPath, directoryPermissions)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	filePath := path.Join(implPath, "impl.go")
+	src, err := os.ReadFile(filePath)
+	switch {
+	case os.IsNotExist(err):
+		src, err = g.newImplSource()
+	case err == nil:
+		src, err = g.appendImplSource(src)
+	}
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	err = os.WriteFile(filePath, src, filePermissions)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// implImports returns the import paths of the stubs with their default names.
+func (g *Generator) implImports() map[string]string {
+	return map[string]string{
+		"context":          "context",
+		g.ImportPrefix:     g.HandlersFile.packageName.Name,
+		g.ModelsImportPath: g.GetCurrentModelsPackage(),
+	}
+}
+
+func (g *Generator) newImplSource() ([]byte, error) {
+	imports := g.implImports()
+	var src bytes.Buffer
+	src.WriteString("// Package " + g.ImplPackage() + " implements the operations of " + path.Base(g.CurrentYAMLFile) + ".\n")
+	src.WriteString("// Methods of new operations are appended by the generator; it never changes existing code.\n")
+	src.WriteString("package " + g.ImplPackage() + "\n\nimport (\n\t\"context\"\n\n")
+	src.WriteString("\t" + strconv.Quote(g.ImportPrefix) + "\n\t" + strconv.Quote(g.ModelsImportPath) + "\n)\n\n")
+	src.WriteString("// Handler implements the handler of every operation, wire it with\n")
+	src.WriteString("// " + g.HandlersFile.packageName.Name + ".NewHandler.\ntype Handler struct{}\n")
+
+	receiver := Field("h", Star(I("Handler")), "")
+	for _, implOp := range g.ImplFile.operations {
+		src.WriteString("\n")
+		err := format.Node(&src, token.NewFileSet(), g.implMethod(implOp, receiver, imports))
+		if err != nil {
+			return nil, err
+		}
+		src.WriteString("\n")
+	}
+
+	return format.Source(src.Bytes())
+}
+
+func (g *Generator) appendImplSource(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "impl.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	implemented := make(map[string]bool)
+	receiverSrc := "h *Handler"
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || !strings.HasPrefix(funcDecl.Name.Name, "Handle") {
+			continue
+		}
+		if len(implemented) == 0 {
+			recv := funcDecl.Recv.List[0]
+			receiverSrc = string(src[fset.Position(recv.Pos()).Offset:fset.Position(recv.End()).Offset])
+		}
+		implemented[funcDecl.Name.Name] = true
+	}
+	receiver, err := parseReceiver(receiverSrc)
+	if err != nil {
+		return nil, err
+	}
+
+	// stubs refer to the packages by the names the file imports them with
+	imports := g.implImports()
+	missing := make(map[string]string, len(imports))
+	for importPath, name := range imports {
+		missing[importPath] = name
+	}
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := imports[importPath]; !ok {
+			continue
+		}
+		delete(missing, importPath)
+		if spec.Name != nil {
+			imports[importPath] = spec.Name.Name
+		}
+	}
+
+	var methods bytes.Buffer
+	for _, implOp := range g.ImplFile.operations {
+		if implemented["Handle"+implOp.baseName] {
+			continue
+		}
+		methods.WriteString("\n")
+		err = format.Node(&methods, token.NewFileSet(), g.implMethod(implOp, receiver, imports))
+		if err != nil {
+			return nil, err
+		}
+		methods.WriteString("\n")
+	}
+	if methods.Len() == 0 {
+		return src, nil
+	}
+
+	var result bytes.Buffer
+	if len(missing) > 0 {
+		// the new imports go in a declaration of their own after the existing ones
+		offset := fset.Position(file.Name.End()).Offset
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+				offset = fset.Position(genDecl.End()).Offset
+			}
+		}
+		result.Write(src[:offset])
+		result.WriteString("\n\nimport (\n")
+		for _, importPath := range sortedKeys(missing) {
+			result.WriteString("\t" + strconv.Quote(importPath) + "\n")
+		}
+		result.WriteString(")")
+		src = src[offset:]
+	}
+	result.Write(src)
+	if !bytes.HasSuffix(src, []byte("\n")) {
+		result.WriteString("\n")
+	}
+	result.Write(methods.Bytes())
+
+	return result.Bytes(), nil
+}
+
+// parseReceiver parses the receiver of a method, like "h *Handler".
+func parseReceiver(receiverSrc string) (*ast.Field, error) {
+	expr, err := parser.ParseExpr("func(" + receiverSrc + ") {}")
+	if err != nil {
+		return nil, errors.Wrap(err, "receiver "+receiverSrc)
+	}
+
+	return expr.(*ast.FuncLit).Type.Params.List[0], nil //nolint:forcetypeassert
+}
+
+// implMethod returns the stub of the operation: it answers with the declared
+// 501 response, or with ErrNotImplemented.
+func (g *Generator) implMethod(implOp implOperation, receiver *ast.Field, imports map[string]string) *ast.FuncDecl {
+	handlers := I(imports[g.ImportPrefix])
+	models := I(imports[g.ModelsImportPath])
+
+	result := Ret2(I("nil"), Sel(handlers, "ErrNotImplemented"))
+	if implOp.notImplemented != "" {
+		result = Ret2(&ast.CallExpr{Fun: Sel(handlers, implOp.baseName+implOp.notImplemented)}, I("nil"))
+	}
+
+	return Func("Handle"+implOp.baseName,
+		receiver,
+		[]*ast.Field{
+			Field("ctx", Sel(I(imports["context"]), "Context"), ""),
+			Field("r", Sel(models, implOp.baseName+"Request"), ""),
+		},
+		[]*ast.Field{
+			Field("", Star(Sel(models, implOp.baseName+"Response")), ""),
+			Field("", I("error"), ""),
+		},
+		[]ast.Stmt{result},
+	)
+}
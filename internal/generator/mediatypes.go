@@ -0,0 +1,231 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const mediaRangeSrc = `package _
+
+// mediaRangeMatches reports whether mediaType, like image/png, is in
+// mediaRange, like image/png, image/* or */*.
+func mediaRangeMatches(mediaRange string, mediaType string) bool {
+	if mediaRange == "*/*" || strings.EqualFold(mediaRange, mediaType) {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+	return ok && len(mediaType) > len(prefix) && strings.EqualFold(mediaType[:len(prefix)+1], prefix+"/")
+}
+`
+
+const negotiationSrc = `package _
+
+// negotiateContentType returns the offer the Accept header of r prefers, the
+// first one when r has no Accept header, or "" when r accepts none of them.
+// The most specific media range matching an offer gives its quality; offers of
+// the same quality are preferred in order.
+func negotiateContentType(r *http.Request, offers ...string) string {
+	accept := strings.Join(r.Header.Values("Accept"), ",")
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		quality, specificity := 0.0, -1
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaRange, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || !mediaRangeMatches(mediaRange, offer) {
+				continue
+			}
+			rangeSpecificity := 2
+			if mediaRange == "*/*" {
+				rangeSpecificity = 0
+			} else if strings.HasSuffix(mediaRange, "/*") {
+				rangeSpecificity = 1
+			}
+			rangeQuality := 1.0
+			if q, ok := params["q"]; ok {
+				rangeQuality, err = strconv.ParseFloat(q, 64)
+				if err != nil {
+					continue
+				}
+			}
+			if rangeSpecificity > specificity || rangeSpecificity == specificity && rangeQuality > quality {
+				quality, specificity = rangeQuality, rangeSpecificity
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+	return best
+}
+`
+
+// isJSONMediaType reports whether bodies of the media type are JSON:
+// application/json, or a type with the +json structured syntax suffix like
+// application/merge-patch+json.
+func isJSONMediaType(contentType string) bool {
+	return contentType == applicationJSONCT || strings.HasSuffix(contentType, "+json")
+}
+
+// defaultRequestContentType returns the media type a request body without a
+// Content-Type is read as: application/json, or else the first JSON media type
+// of contentTypes, "" when none is JSON.
+func defaultRequestContentType(contentTypes []string) string {
+	if slices.Contains(contentTypes, applicationJSONCT) {
+		return applicationJSONCT
+	}
+	for _, contentType := range contentTypes {
+		if isJSONMediaType(contentType) {
+			return contentType
+		}
+	}
+
+	return ""
+}
+
+// requestContentTypes returns the media types of the request body of the
+// operation in order, none without a body, and checks that they are supported:
+// JSON, form and multipart bodies, and any other media type read as is from a
+// binary schema.
+func requestContentTypes(where string, operation *openapi3.Operation) ([]string, error) {
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return nil, nil
+	}
+	contentTypes := sortedKeys(operation.RequestBody.Value.Content)
+	for _, contentType := range contentTypes {
+		switch {
+		case isJSONMediaType(contentType), contentType == applicationFormCT, contentType == multipartFormCT:
+		case isBinaryContent(contentType, operation.RequestBody.Value.Content[contentType]) && !strings.Contains(contentType, "*"):
+		default:
+			return nil, errors.Errorf("unsupported content type %s of %s", contentType, where)
+		}
+	}
+	err := checkBodyVariants(where, contentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return contentTypes, nil
+}
+
+// responseContentTypes returns the media types of the response in order and
+// checks that they are supported: JSON types are encoded from the body model,
+// binary schemas are streamed and the others are written from a string as is. A response of several content
+// types needs a schema for each of them, the bodies it may be negotiated to.
+func responseContentTypes(where string, response *openapi3.ResponseRef) ([]string, error) {
+	contentTypes := sortedKeys(response.Value.Content)
+	for _, contentType := range contentTypes {
+		schema := response.Value.Content[contentType].Schema
+		switch {
+		case schema == nil:
+			if len(contentTypes) > 1 {
+				return nil, errors.Errorf("content type %s of %s has no schema to negotiate", contentType, where)
+			}
+		case isJSONMediaType(contentType):
+		case isBinarySchema(schema.Value):
+		case !schema.Value.Type.Is(openapi3.TypeString):
+			return nil, errors.Errorf("content type %s of %s needs a string schema", contentType, where)
+		}
+	}
+	err := checkBodyVariants(where, contentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return contentTypes, nil
+}
+
+// checkBodyVariants checks that the bodies of the media types, read into or
+// written from fields of the same model, have distinct names.
+func checkBodyVariants(where string, contentTypes []string) error {
+	variants := make(map[string]string, len(contentTypes))
+	for _, contentType := range contentTypes {
+		variant := bodyVariant(contentType)
+		if other, ok := variants[variant]; ok {
+			return errors.Errorf("content types %s and %s of %s share the body field %sBody",
+				other, contentType, where, variant)
+		}
+		variants[variant] = contentType
+	}
+
+	return nil
+}
+
+// bodyVariant returns the name the body of the media type is known by among the
+// bodies of an operation or a response: JSON, Form, Multipart, Text, Raw, or the
+// subtype otherwise, like MergePatchJSON, CSV or XML.
+func bodyVariant(contentType string) string {
+	switch contentType {
+	case applicationJSONCT:
+		return "JSON"
+	case applicationFormCT:
+		return "Form"
+	case multipartFormCT:
+		return "Multipart"
+	case "text/plain":
+		return "Text"
+	case applicationOctetStreamCT:
+		return "Raw"
+	}
+	_, subtype, _ := strings.Cut(contentType, "/")
+	subtype = strings.NewReplacer(".", "-", "+", "-").Replace(strings.TrimPrefix(subtype, "vnd."))
+
+	return FormatGoLikeIdentifier(subtype)
+}
+
+// requestBodyName returns the base name of the model and the parser of the body
+// of the media type: the one of the operation when it accepts a single content
+// type, followed by the variant otherwise.
+func requestBodyName(baseName string, contentType string, contentTypes []string) string {
+	if len(contentTypes) <= 1 {
+		return baseName
+	}
+
+	return baseName + bodyVariant(contentType)
+}
+
+// responseBodyField returns the field of the response model holding the body
+// of the media type: Body when the response has a single content type, the
+// variant followed by Body otherwise.
+func responseBodyField(contentType string, contentTypes []string) string {
+	if len(contentTypes) <= 1 {
+		return "Body"
+	}
+
+	return bodyVariant(contentType) + "Body"
+}
+
+// AddMediaTypeDecls adds the helpers matching media ranges, when multipart
+// bodies check the content type of their files or responses are negotiated on
+// the Accept header of the request.
+func (g *Generator) AddMediaTypeDecls() {
+	if len(g.HandlersFile.multipart) == 0 && !g.HandlersFile.hasNegotiatedResponses {
+		return
+	}
+	srcs := []string{mediaRangeSrc}
+	g.AddHandlersImport("strings")
+	if g.HandlersFile.hasNegotiatedResponses {
+		srcs = append(srcs, negotiationSrc)
+		for _, path := range []string{"mime", "net/http", "strconv"} {
+			g.AddHandlersImport(path)
+		}
+	}
+
+	for _, src := range srcs {
+		file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+		if err != nil {
+			panic(err)
+		}
+		g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+	}
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+const operationMiddlewareSrc = `package _
+
+// OperationID identifies an operation by the operationId of the spec.
+type OperationID string
+
+type operationIDKey struct{}
+
+// OperationIDFromContext returns the operation serving the request of ctx.
+func OperationIDFromContext(ctx context.Context) (OperationID, bool) {
+	id, ok := ctx.Value(operationIDKey{}).(OperationID)
+	return id, ok
+}
+
+// WithOperationMiddleware wraps the handler of the operation id with mw, the
+// first one outermost, after the middleware of previous options.
+func WithOperationMiddleware(id OperationID, mw ...func(http.Handler) http.Handler) Option {
+	return func(h *Handler) {
+		if h.middlewares == nil {
+			h.middlewares = make(map[OperationID][]func(http.Handler) http.Handler)
+		}
+		h.middlewares[id] = append(h.middlewares[id], mw...)
+	}
+}
+
+func (h *Handler) operationHandler(id OperationID, handle http.HandlerFunc) http.Handler {
+	var handler http.Handler = handle
+	middlewares := h.middlewares[id]
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), operationIDKey{}, id)))
+	})
+}
+`
+
+func (g *Generator) AddOperationMiddlewareDecls() {
+	g.AddHandlersImport("context")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", operationMiddlewareSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// AddOperationID declares Operation<Op>, the OperationID of the operation.
+func (g *Generator) AddOperationID(baseName string, operationID string) {
+	g.HandlersFile.operationIDs = append(g.HandlersFile.operationIDs, &ast.ValueSpec{
+		Names:  []*ast.Ident{I("Operation" + baseName)},
+		Type:   I("OperationID"),
+		Values: []ast.Expr{Str(operationID)},
+	})
+}
+
+// operationHandlerExpr returns the handler of the operation, wrapped in its
+// middleware.
+func operationHandlerExpr(baseName string) ast.Expr {
+	return &ast.CallExpr{
+		Fun:  Sel(I("h"), "operationHandler"),
+		Args: []ast.Expr{I("Operation" + baseName), Sel(I("h"), "handle"+baseName)},
+	}
+}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+const mocksSrc = `package _
+
+// HandlerMock records the requests of an operation and answers them with Func.
+type HandlerMock[Req any, Resp any] struct {
+	Func func(ctx context.Context, r Req) (*Resp, error)
+
+	mu    sync.Mutex
+	calls []Req
+}
+
+// Calls returns the requests received so far.
+func (m *HandlerMock[Req, Resp]) Calls() []Req {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
+
+// Returns makes the mock answer every request with response.
+func (m *HandlerMock[Req, Resp]) Returns(response *Resp) {
+	m.Func = func(ctx context.Context, r Req) (*Resp, error) {
+		return response, nil
+	}
+}
+
+// ReturnsError makes the mock fail every request with err.
+func (m *HandlerMock[Req, Resp]) ReturnsError(err error) {
+	m.Func = func(ctx context.Context, r Req) (*Resp, error) {
+		return nil, err
+	}
+}
+
+func (m *HandlerMock[Req, Resp]) handle(ctx context.Context, method string, r Req) (*Resp, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, r)
+	m.mu.Unlock()
+	if m.Func == nil {
+		return nil, errors.New(method + " is not mocked")
+	}
+	return m.Func(ctx, r)
+}
+`
+
+type MocksFile struct {
+	operations []string
+}
+
+func (g *Generator) NewMocksFile() {
+	g.MocksFile = &MocksFile{}
+}
+
+// AddMock registers the operation whose handler interface gets a mock.
+func (g *Generator) AddMock(baseName string) {
+	if !g.Opts.Mocks {
+		return
+	}
+	g.MocksFile.operations = append(g.MocksFile.operations, baseName)
+}
+
+// HasMocks reports whether mocks.go is generated for the spec.
+func (g *Generator) HasMocks() bool {
+	return len(g.MocksFile.operations) > 0
+}
+
+func (g *Generator) WriteMocksToOutput(output io.Writer) error {
+	const op = "generator.MocksFile.WriteToOutput"
+	_, err := output.Write([]byte("// Code generated by github.com/sintoniastrategy/validgo-gen; DO NOT EDIT.\n\n"))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	file := g.GenerateMocksFile()
+	err = format.Node(output, token.NewFileSet(), file)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+func (g *Generator) GenerateMocksFile() *ast.File {
+	staticFile, err := parser.ParseFile(token.NewFileSet(), "", mocksSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	importSpecs, declSpecs := g.GenerateImportsSpecs([]string{
+		"context", "slices", "sync",
+		"github.com/go-faster/errors",
+		g.ModelsImportPath,
+	})
+	file := &ast.File{
+		Name:    g.HandlersFile.packageName,
+		Imports: importSpecs,
+		Decls: []ast.Decl{&ast.GenDecl{
+			Tok:   token.IMPORT,
+			Specs: declSpecs,
+		}},
+	}
+	file.Decls = append(file.Decls, staticFile.Decls...)
+	for _, baseName := range g.MocksFile.operations {
+		file.Decls = append(file.Decls, g.mockDecls(baseName)...)
+	}
+	file.Decls = append(file.Decls, g.authenticatorMockDecls()...)
+	file.Decls = append(file.Decls, g.testHandlerDecls()...)
+
+	return file
+}
+
+// mockDecls returns <Op>HandlerMock, implementing <Op>Handler with a HandlerMock.
+func (g *Generator) mockDecls(baseName string) []ast.Decl {
+	models := g.GetCurrentModelsPackage()
+	mockName := baseName + "HandlerMock"
+	methodName := "Handle" + baseName
+
+	return []ast.Decl{
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{&ast.TypeSpec{
+				Name: I(mockName),
+				Type: &ast.StructType{Fields: &ast.FieldList{List: []*ast.Field{
+					Field("", &ast.IndexListExpr{
+						X: I("HandlerMock"),
+						Indices: []ast.Expr{
+							Sel(I(models), baseName+"Request"),
+							Sel(I(models), baseName+"Response"),
+						},
+					}, ""),
+				}}},
+			}},
+		},
+		Func(methodName,
+			Field("m", Star(I(mockName)), ""),
+			[]*ast.Field{
+				Field("ctx", Sel(I("context"), "Context"), ""),
+				Field("r", Sel(I(models), baseName+"Request"), ""),
+			},
+			[]*ast.Field{
+				Field("", Star(Sel(I(models), baseName+"Response")), ""),
+				Field("", I("error"), ""),
+			},
+			[]ast.Stmt{Ret1(&ast.CallExpr{
+				Fun:  Sel(I("m"), "handle"),
+				Args: []ast.Expr{I("ctx"), Str(methodName), I("r")},
+			})},
+		),
+	}
+}
+
+// authenticatorMockDecls returns <Scheme>AuthenticatorMock for every security
+// scheme in use: it accepts any credentials, with a nil principal, until its
+// Func is set.
+func (g *Generator) authenticatorMockDecls() []ast.Decl {
+	if len(g.HandlersFile.securitySchemes) == 0 {
+		return nil
+	}
+
+	var src strings.Builder
+	src.WriteString("package _\n")
+	for _, scheme := range g.HandlersFile.securitySchemes {
+		params, _, args, _ := scheme.credentials()
+		mockName := scheme.goName + "AuthenticatorMock"
+		src.WriteString("\ntype " + mockName + " struct {\n")
+		src.WriteString("\tFunc func(ctx context.Context, " + params + ") (any, error)\n}\n")
+		src.WriteString("\nfunc (m *" + mockName + ") Authenticate" + scheme.goName + "(ctx context.Context, " + params + ") (any, error) {\n")
+		src.WriteString("\tif m.Func == nil {\n\t\treturn nil, nil\n\t}\n")
+		src.WriteString("\treturn m.Func(ctx, " + args + ")\n}\n")
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "", src.String(), 0)
+	if err != nil {
+		panic(err)
+	}
+
+	return file.Decls
+}
+
+// testHandlerDecls returns TestHandler, a Handler serving the mocks of every
+// operation, and its constructor NewTestHandler.
+func (g *Generator) testHandlerDecls() []ast.Decl {
+	fields := []*ast.Field{Field("", Star(I("Handler")), "")}
+	mocks := make([]ast.Expr, 0, len(g.MocksFile.operations))
+	handlerArgs := make([]ast.Expr, 0, len(g.MocksFile.operations)+1)
+	for _, baseName := range g.MocksFile.operations {
+		fields = append(fields, Field(baseName, Star(I(baseName+"HandlerMock")), ""))
+		mocks = append(mocks, &ast.KeyValueExpr{
+			Key:   I(baseName),
+			Value: Amp(&ast.CompositeLit{Type: I(baseName + "HandlerMock")}),
+		})
+		handlerArgs = append(handlerArgs, Sel(I("th"), baseName))
+	}
+	for _, scheme := range g.HandlersFile.securitySchemes {
+		mockName := scheme.goName + "AuthenticatorMock"
+		fields = append(fields, Field(scheme.goName+"Authenticator", Star(I(mockName)), ""))
+		mocks = append(mocks, &ast.KeyValueExpr{
+			Key:   I(scheme.goName + "Authenticator"),
+			Value: Amp(&ast.CompositeLit{Type: I(mockName)}),
+		})
+		handlerArgs = append(handlerArgs, Sel(I("th"), scheme.goName+"Authenticator"))
+	}
+	handlerArgs = append(handlerArgs, I("opts"))
+
+	return []ast.Decl{
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{&ast.TypeSpec{
+				Name: I("TestHandler"),
+				Type: &ast.StructType{Fields: &ast.FieldList{List: fields}},
+			}},
+		},
+		Func("NewTestHandler",
+			nil,
+			[]*ast.Field{
+				Field("opts", &ast.Ellipsis{Elt: I("Option")}, ""),
+			},
+			[]*ast.Field{
+				Field("", Star(I("TestHandler")), ""),
+			},
+			[]ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{I("th")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{Amp(&ast.CompositeLit{Type: I("TestHandler"), Elts: mocks})},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{Sel(I("th"), "Handler")},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun:      I("NewHandler"),
+						Args:     handlerArgs,
+						Ellipsis: 1,
+					}},
+				},
+				Ret1(I("th")),
+			},
+		),
+	}
+}
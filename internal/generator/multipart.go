@@ -0,0 +1,464 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const multipartFormCT = "multipart/form-data"
+
+const multipartFileModelSrc = `package _
+
+// MultipartFile is a file part of a multipart/form-data body.
+type MultipartFile struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
+`
+
+const multipartSrc = `package _
+
+// WithMultipartLimits sets the bytes of a multipart/form-data body kept in
+// memory, the rest of the files being stored on disk, and the maximum size of
+// the body, 0 for no limit.
+func WithMultipartLimits(maxMemory int64, maxSize int64) Option {
+	return func(h *Handler) {
+		h.multipartMaxMemory = maxMemory
+		h.multipartMaxSize = maxSize
+	}
+}
+
+// limitMultipartBody caps the multipart/form-data body of r at the maximum size
+// of h: reading past it fails with an *http.MaxBytesError.
+func (h *Handler) limitMultipartBody(w http.ResponseWriter, r *http.Request) {
+	if h.multipartMaxSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.multipartMaxSize)
+	}
+}
+
+// multipartFiles are the file parts of a request opened so far, closed once
+// the request is handled.
+type multipartFiles []multipart.File
+
+// open opens the file part fh, whose media type must match one of
+// contentTypes when the encoding of the part declares them.
+func (f *multipartFiles) open(fh *multipart.FileHeader, contentTypes ...string) (multipart.File, error) {
+	if len(contentTypes) > 0 {
+		mediaType, _, err := mime.ParseMediaType(fh.Header.Get("Content-Type"))
+		if err != nil {
+			mediaType = "application/octet-stream"
+		}
+		if !slices.ContainsFunc(contentTypes, func(mediaRange string) bool {
+			return mediaRangeMatches(mediaRange, mediaType)
+		}) {
+			return nil, &FieldError{
+				Rule:    "contentType",
+				Message: "content type " + mediaType + " is not one of " + strings.Join(contentTypes, ", "),
+			}
+		}
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	*f = append(*f, file)
+	return file, nil
+}
+
+// close closes every file part opened so far.
+func (f multipartFiles) close() {
+	for _, file := range f {
+		file.Close()
+	}
+}
+`
+
+const multipartClientSrc = `package _
+
+// multipartBody streams the parts written by write as a multipart/form-data
+// body, and returns it with its content type.
+func multipartBody(write func(mw *multipart.Writer) error) (io.Reader, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		err := write(mw)
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, mw.FormDataContentType()
+}
+
+// writeMultipartFields writes a part for every value of fields.
+func writeMultipartFields(mw *multipart.Writer, fields url.Values) error {
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		for _, value := range fields[name] {
+			err := mw.WriteField(name, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+
+// writeMultipartFile writes the file part name, application/octet-stream
+// unless contentType says otherwise.
+func writeMultipartFile(mw *multipart.Writer, name string, filename string, contentType string, content io.Reader) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition",
+		"form-data; name=\""+quoteEscaper.Replace(name)+"\"; filename=\""+quoteEscaper.Replace(filename)+"\"")
+	header.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		return nil
+	}
+	_, err = io.Copy(part, content)
+	return err
+}
+`
+
+// isBinarySchema reports whether the schema is a file: a string of format
+// binary.
+func isBinarySchema(schema *openapi3.Schema) bool {
+	return schema.Type.Permits(openapi3.TypeString) && schema.Format == "binary"
+}
+
+// AddMultipartFileModel adds the MultipartFile type binary strings are read
+// into to the models.
+func (g *Generator) AddMultipartFileModel() {
+	if g.SchemasFile.hasMultipartFile {
+		return
+	}
+	g.SchemasFile.hasMultipartFile = true
+	g.AddSchemasImport("io")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", multipartFileModelSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, file.Decls...)
+}
+
+// AddMultipartDecls adds the option and helpers reading multipart/form-data
+// bodies, when an operation accepts one.
+func (g *Generator) AddMultipartDecls() {
+	if len(g.HandlersFile.multipart) == 0 {
+		return
+	}
+	for _, path := range []string{"mime", "mime/multipart", "net/http", "slices", "strings"} {
+		g.AddHandlersImport(path)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", multipartSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// addMultipartDependencies adds the limits of multipart/form-data bodies, 32 MB
+// in memory like net/http and 64 MB in all by default, to the Handler.
+func (g *Generator) addMultipartDependencies() {
+	if len(g.HandlersFile.multipart) == 0 {
+		return
+	}
+	g.HandlersFile.handlerDeclQAFieldList.List = append(g.HandlersFile.handlerDeclQAFieldList.List,
+		Field("multipartMaxMemory", I("int64"), ""),
+		Field("multipartMaxSize", I("int64"), ""),
+	)
+	g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts = append(
+		g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts,
+		&ast.KeyValueExpr{
+			Key:   I("multipartMaxMemory"),
+			Value: &ast.BinaryExpr{X: intLit("32"), Op: token.SHL, Y: intLit("20")},
+		},
+		&ast.KeyValueExpr{
+			Key:   I("multipartMaxSize"),
+			Value: &ast.BinaryExpr{X: intLit("64"), Op: token.SHL, Y: intLit("20")},
+		},
+	)
+}
+
+// openedFilesDecl returns the declaration of the file parts opened by the
+// parser of a multipart/form-data body, nil for other operations.
+func (g *Generator) openedFilesDecl(baseName string) []ast.Stmt {
+	if !g.HandlersFile.multipart[baseName] {
+		return nil
+	}
+
+	return []ast.Stmt{&ast.DeclStmt{Decl: &ast.GenDecl{
+		Tok:   token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I("opened")}, Type: I("multipartFiles")}},
+	}}}
+}
+
+// removeMultipartFormStmts returns the statements closing the opened file parts
+// and removing the files of the multipart/form-data body stored on disk once
+// the handler and the response are done: the files are closed first.
+func (g *Generator) removeMultipartFormStmts(baseName string) []ast.Stmt {
+	if !g.HandlersFile.multipart[baseName] {
+		return nil
+	}
+
+	return []ast.Stmt{
+		&ast.IfStmt{
+			Cond: Ne(Sel(I("r"), "MultipartForm"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.DeferStmt{Call: &ast.CallExpr{
+				Fun: Sel(Sel(I("r"), "MultipartForm"), "RemoveAll"),
+			}}}},
+		},
+		&ast.DeferStmt{Call: &ast.CallExpr{Fun: Sel(I("opened"), "close")}},
+	}
+}
+
+// multipartPreamble returns the statements reading the multipart/form-data body
+// into the form values and files.
+func (g *Generator) multipartPreamble(hasValues bool, hasFiles bool, required bool) []ast.Stmt {
+	var result []ast.Stmt
+	if !required {
+		result = append(result, &ast.IfStmt{
+			Cond: Eq(Sel(I("r"), "ContentLength"), intLit("0")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("nil"))}},
+		})
+	}
+	result = append(result,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("r"), "ParseMultipartForm"),
+				Args: []ast.Expr{Sel(I("h"), "multipartMaxMemory")},
+			}},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
+		},
+	)
+	if hasValues {
+		g.AddHandlersImport("net/url")
+		result = append(result, &ast.AssignStmt{
+			Lhs: []ast.Expr{I("form")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("url"), "Values"),
+				Args: []ast.Expr{Sel(Sel(I("r"), "MultipartForm"), "Value")},
+			}},
+		})
+	}
+	if hasFiles {
+		result = append(result, &ast.AssignStmt{
+			Lhs: []ast.Expr{I("files")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{Sel(Sel(I("r"), "MultipartForm"), "File")},
+		})
+	}
+
+	return result
+}
+
+// encodingContentTypes returns the media ranges a file part must match, none
+// when the encoding does not restrict them.
+func encodingContentTypes(encoding *openapi3.Encoding) []string {
+	if encoding == nil {
+		return nil
+	}
+	var result []string
+	for _, contentType := range strings.Split(encoding.ContentType, ",") {
+		contentType = strings.ToLower(strings.TrimSpace(contentType))
+		if contentType != "" {
+			result = append(result, contentType)
+		}
+	}
+
+	return result
+}
+
+// parseMultipartFileField returns the statements reading the file parts
+// propName into body, a MultipartFile or a slice of them for an array.
+func (g *Generator) parseMultipartFileField(sliceType ast.Expr, fileType ast.Expr, propName string,
+	schema *openapi3.SchemaRef, encoding *openapi3.Encoding, required bool,
+) []ast.Stmt {
+	g.AddHandlersImport("mime/multipart")
+	fieldName := FormatGoLikeIdentifier(propName)
+	filesName := "form" + fieldName + "Files"
+	pointer := !required || g.HandlersFile.requiredFieldsArePointers
+
+	// openFile opens the file part fh into the variable name
+	openFile := func(fh ast.Expr, name string) []ast.Stmt {
+		args := []ast.Expr{fh}
+		for _, contentType := range encodingContentTypes(encoding) {
+			args = append(args, Str(contentType))
+		}
+		return []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("content"), I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("opened"), "open"), Args: args}},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I(name)},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CompositeLit{
+					Type: fileType,
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{Key: I("Filename"), Value: Sel(fh, "Filename")},
+						&ast.KeyValueExpr{Key: I("ContentType"), Value: &ast.CallExpr{
+							Fun:  Sel(Sel(fh, "Header"), "Get"),
+							Args: []ast.Expr{Str("Content-Type")},
+						}},
+						&ast.KeyValueExpr{Key: I("Size"), Value: Sel(fh, "Size")},
+						&ast.KeyValueExpr{Key: I("Content"), Value: I("content")},
+					},
+				}},
+			},
+		}
+	}
+
+	var assign []ast.Stmt
+	var value ast.Expr
+	if schema.Value.Type.Permits(openapi3.TypeArray) {
+		itemsName := "form" + fieldName + "Items"
+		assign = []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I(itemsName)},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun: I("make"),
+					Args: []ast.Expr{
+						sliceType,
+						intLit("0"),
+						&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(filesName)}},
+					},
+				}},
+			},
+			&ast.RangeStmt{
+				Key:   I("_"),
+				Value: I("fh"),
+				Tok:   token.DEFINE,
+				X:     I(filesName),
+				Body: &ast.BlockStmt{List: append(openFile(I("fh"), "file"), &ast.AssignStmt{
+					Lhs: []ast.Expr{I(itemsName)},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun:  I("append"),
+						Args: []ast.Expr{I(itemsName), I("file")},
+					}},
+				})},
+			},
+		}
+		value = I(itemsName)
+	} else {
+		varName := "form" + fieldName
+		assign = openFile(&ast.IndexExpr{X: I(filesName), Index: intLit("0")}, varName)
+		value = I(varName)
+	}
+	if pointer {
+		value = Amp(value)
+	}
+	assign = append(assign, &ast.AssignStmt{
+		Lhs: []ast.Expr{Sel(I("body"), fieldName)},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{value},
+	})
+
+	result := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{I(filesName)},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.IndexExpr{X: I("files"), Index: Str(propName)}},
+	}}
+	if required {
+		result = append(result, &ast.IfStmt{
+			Cond: Eq(&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(filesName)}}, intLit("0")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"),
+				requiredParamError("field "+propName+" is required"),
+			)}},
+		})
+
+		return append(result, assign...)
+	}
+
+	return append(result, &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(filesName)}},
+			Op: token.GTR,
+			Y:  intLit("0"),
+		},
+		Body: &ast.BlockStmt{List: assign},
+	})
+}
+
+// AddMultipartClientDecls adds the helpers streaming multipart/form-data
+// bodies to the client.
+func (g *Generator) AddMultipartClientDecls() {
+	if g.ClientFile.hasMultipart {
+		return
+	}
+	g.ClientFile.hasMultipart = true
+	for _, path := range []string{"io", "maps", "mime/multipart", "net/textproto", "net/url", "slices", "strings"} {
+		g.AddClientImport(path)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", multipartClientSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.ClientFile.extraDecls = append(g.ClientFile.extraDecls, file.Decls...)
+}
+
+// clientMultipartFileStmts returns the statements writing the file value, or
+// every file of an array, as parts named propName.
+func clientMultipartFileStmts(propName string, schema *openapi3.SchemaRef, value ast.Expr) []ast.Stmt {
+	if _, ok := value.(*ast.StarExpr); ok {
+		value = &ast.ParenExpr{X: value}
+	}
+	writeFile := func(file ast.Expr) ast.Stmt {
+		return &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun: I("writeMultipartFile"),
+					Args: []ast.Expr{
+						I("mw"), Str(propName),
+						Sel(file, "Filename"), Sel(file, "ContentType"), Sel(file, "Content"),
+					},
+				}},
+			},
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+		}
+	}
+
+	if schema.Value.Type.Permits(openapi3.TypeArray) {
+		return []ast.Stmt{&ast.RangeStmt{
+			Key:   I("_"),
+			Value: I("file"),
+			Tok:   token.DEFINE,
+			X:     value,
+			Body:  &ast.BlockStmt{List: []ast.Stmt{writeFile(I("file"))}},
+		}}
+	}
+
+	return []ast.Stmt{writeFile(value)}
+}
@@ -13,6 +13,7 @@ var commonInitialisms = map[string]bool{
 	"ASCII": true,
 	"CPU":   true,
 	"CSS":   true,
+	"CSV":   true,
 	"DNS":   true,
 	"EOF":   true,
 	"FQDN":  true,
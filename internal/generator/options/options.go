@@ -6,6 +6,12 @@ import (
 	"github.com/go-faster/errors"
 )
 
+// Routers AddRoutes can register the routes on.
+const (
+	RouterChi      = "chi"
+	RouterServeMux = "servemux"
+)
+
 type Options struct {
 	PackagePrefix             string
 	DirPrefix                 string
@@ -13,6 +19,11 @@ type Options struct {
 	RequiredFieldsArePointers bool
 	AllowDeleteWithBody       bool
 	AllowRemoteAddrParam      bool
+	StrictJSON                bool
+	Mocks                     bool
+	ImplDir                   string
+	Router                    string
+	OTel                      bool
 }
 
 func GetOptions() (*Options, error) {
@@ -23,6 +34,11 @@ func GetOptions() (*Options, error) {
 	flag.BoolVar(&opts.RequiredFieldsArePointers, "pointers", false, "Generate required fields as pointers")
 	flag.BoolVar(&opts.AllowDeleteWithBody, "allow-delete-with-body", false, "Allow DELETE operations with a body")
 	flag.BoolVar(&opts.AllowRemoteAddrParam, "allow-remote-addr-param", false, "Allow RemoteAddr fake parameter")
+	flag.BoolVar(&opts.StrictJSON, "strict", false, "Reject unknown fields in JSON bodies")
+	flag.BoolVar(&opts.Mocks, "mocks", false, "Generate mocks of the handler interfaces")
+	flag.StringVar(&opts.ImplDir, "impl", "", "Directory to scaffold handler implementation packages in")
+	flag.StringVar(&opts.Router, "router", RouterChi, "Router to register the routes on: chi or servemux")
+	flag.BoolVar(&opts.OTel, "otel", false, "Trace and measure the requests with OpenTelemetry")
 
 	flag.Parse()
 	opts.YAMLFiles = flag.Args()
@@ -30,6 +46,9 @@ func GetOptions() (*Options, error) {
 	if len(opts.YAMLFiles) == 0 {
 		return nil, errors.New("at least one file must be provided")
 	}
+	if opts.Router != RouterChi && opts.Router != RouterServeMux {
+		return nil, errors.Errorf("unknown router %q", opts.Router)
+	}
 
 	return &opts, nil
 }
@@ -0,0 +1,450 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+// queryArraySeparators maps the non-exploded query styles to their delimiter.
+var queryArraySeparators = map[string]string{
+	openapi3.SerializationForm:           ",",
+	openapi3.SerializationSpaceDelimited: " ",
+	openapi3.SerializationPipeDelimited:  "|",
+}
+
+// paramSerialization returns the effective style and explode settings of a
+// query parameter. Unlike kin-openapi, explode only defaults to true for the
+// form style, as the OpenAPI specification requires.
+func paramSerialization(param *openapi3.Parameter) (string, bool) {
+	return serialization(param.Style, param.Explode)
+}
+
+// encodingSerialization returns the effective style and explode settings of a
+// property of a form body, with the defaults of paramSerialization.
+func encodingSerialization(encoding *openapi3.Encoding) (string, bool) {
+	if encoding == nil {
+		return serialization("", nil)
+	}
+
+	return serialization(encoding.Style, encoding.Explode)
+}
+
+func serialization(style string, explode *bool) (string, bool) {
+	if style == "" {
+		style = openapi3.SerializationForm
+	}
+	if explode == nil {
+		return style, style == openapi3.SerializationForm
+	}
+
+	return style, *explode
+}
+
+// scalarParseCall returns the call converting the string variable varName into
+// the Go type of schema, the error message used when it fails and the type the
+// parsed value must be cast to (empty when no cast is needed).
+func (g *Generator) scalarParseCall(schema *openapi3.SchemaRef, varName string) (ast.Expr, string, string) {
+	switch {
+	case schema.Value.Type.Permits(openapi3.TypeInteger):
+		g.AddHandlersImport("strconv")
+		goType := g.GetIntegerType(schema.Value.Format)
+		call, needsCast := numericIntParseCall(goType, varName)
+		if !needsCast {
+			goType = ""
+		}
+		return call, "is not a valid integer", goType
+	case schema.Value.Type.Permits(openapi3.TypeNumber):
+		g.AddHandlersImport("strconv")
+		return &ast.CallExpr{
+			Fun:  Sel(I("strconv"), "ParseFloat"),
+			Args: []ast.Expr{I(varName), intLit("64")},
+		}, "is not a valid number", ""
+	case schema.Value.Type.Permits(openapi3.TypeBoolean):
+		g.AddHandlersImport("strconv")
+		return &ast.CallExpr{
+			Fun:  Sel(I("strconv"), "ParseBool"),
+			Args: []ast.Expr{I(varName)},
+		}, "is not a valid boolean", ""
+	}
+	call, errMsg := g.stringParseCall(schema.Value.Format, varName)
+
+	return call, errMsg, ""
+}
+
+func (g *Generator) parseArrayQueryParam(param *openapi3.Parameter) ([]ast.Stmt, error) {
+	fieldName := FormatGoLikeIdentifier(param.Name)
+	varName := GoIdentLowercase(fieldName)
+	valuesName := varName + "Values"
+	queryCall := &ast.CallExpr{
+		Fun:  Sel(Sel(I("r"), "URL"), "Query"),
+		Args: []ast.Expr{},
+	}
+
+	style, explode := paramSerialization(param)
+	separator, ok := queryArraySeparators[style]
+	if !ok {
+		return nil, errors.New("unsupported style " + style + " for array query parameter " + param.Name)
+	}
+
+	var result []ast.Stmt
+	if explode {
+		// tag=a&tag=b
+		result = append(result, &ast.AssignStmt{
+			Lhs: []ast.Expr{I(valuesName)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.IndexExpr{X: queryCall, Index: Str(param.Name)}},
+		})
+	} else {
+		// ids=1,2,3 / ids=1%202%203 / ids=1|2|3
+		g.AddHandlersImport("strings")
+		result = append(result,
+			&ast.DeclStmt{
+				Decl: &ast.GenDecl{
+					Tok: token.VAR,
+					Specs: []ast.Spec{
+						&ast.ValueSpec{
+							Names: []*ast.Ident{I(valuesName)},
+							Type:  &ast.ArrayType{Elt: I("string")},
+						},
+					},
+				},
+			},
+			&ast.IfStmt{
+				Init: &ast.AssignStmt{
+					Lhs: []ast.Expr{I(varName)},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun:  Sel(queryCall, "Get"),
+						Args: []ast.Expr{Str(param.Name)},
+					}},
+				},
+				Cond: Ne(I(varName), Str("")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I(valuesName)},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{&ast.CallExpr{
+							Fun:  Sel(I("strings"), "Split"),
+							Args: []ast.Expr{I(varName), Str(separator)},
+						}},
+					},
+				}},
+			},
+		)
+	}
+
+	assignStmts := g.assignArrayParamField("queryParams", valuesName, fieldName, param.Schema, param.Required)
+	if param.Required {
+		result = append(result, &ast.IfStmt{
+			Cond: Eq(&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(valuesName)}}, intLit("0")),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{Ret2(I("nil"),
+					requiredParamError(param.Name+" query param is required"),
+				)},
+			},
+		})
+
+		return append(result, assignStmts...), nil
+	}
+
+	return append(result, &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(valuesName)}},
+			Op: token.GTR,
+			Y:  intLit("0"),
+		},
+		Body: &ast.BlockStmt{List: assignStmts},
+	}), nil
+}
+
+// assignArrayParamField converts every raw string in valuesName into the item
+// type of the array schema and stores the result in paramsName.fieldName.
+// Conversion errors are declared inside the loop and never leak to the caller.
+func (g *Generator) assignArrayParamField(paramsName, valuesName, fieldName string, schema *openapi3.SchemaRef,
+	required bool,
+) []ast.Stmt {
+	pointer := !required || g.HandlersFile.requiredFieldsArePointers
+	assign := func(value string) *ast.AssignStmt {
+		var rhs ast.Expr = I(value)
+		if pointer {
+			rhs = Amp(rhs)
+		}
+
+		return &ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I(paramsName), fieldName)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{rhs},
+		}
+	}
+
+	items := schema.Value.Items
+	parseCall, errMsg, castType := g.scalarParseCall(items, "item")
+	if parseCall == nil {
+		return []ast.Stmt{assign(valuesName)}
+	}
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	elemType := castType
+	if elemType == "" {
+		elemType, _ = g.GetParamFieldType(fieldName, items)
+	}
+	itemsName := GoIdentLowercase(fieldName) + "Items"
+	var itemExpr ast.Expr = I("parsedItem")
+	if castType != "" {
+		itemExpr = &ast.CallExpr{Fun: I(castType), Args: []ast.Expr{itemExpr}}
+	}
+
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I(itemsName)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: I("make"),
+				Args: []ast.Expr{
+					&ast.ArrayType{Elt: I(elemType)},
+					intLit("0"),
+					&ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I(valuesName)}},
+				},
+			}},
+		},
+		&ast.RangeStmt{
+			Key:   I("_"),
+			Value: I("item"),
+			Tok:   token.DEFINE,
+			X:     I(valuesName),
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{I("parsedItem"), I("err")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{parseCall},
+				},
+				&ast.IfStmt{
+					Cond: Ne(I("err"), I("nil")),
+					Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(
+						I("nil"),
+						&ast.CallExpr{
+							Fun:  Sel(I("errors"), "Wrap"),
+							Args: []ast.Expr{I("err"), Str(fieldName + " item " + errMsg)},
+						},
+					)}},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{I(itemsName)},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.CallExpr{
+						Fun:  I("append"),
+						Args: []ast.Expr{I(itemsName), itemExpr},
+					}},
+				},
+			}},
+		},
+		assign(itemsName),
+	}
+}
+
+// AddObjectParamModel generates the struct backing an object query parameter and
+// returns its Go type. Only flat objects can be serialized into a query string, so
+// every property must be a scalar.
+func (g *Generator) AddObjectParamModel(modelName string, schema *openapi3.SchemaRef) (string, error) {
+	const op = "generator.AddObjectParamModel"
+	if len(schema.Value.Properties) == 0 {
+		return "", errors.New("object parameter " + modelName + " must declare properties")
+	}
+	for propName, propSchema := range schema.Value.Properties {
+		if propSchema.Value.Type.Permits(openapi3.TypeObject) || propSchema.Value.Type.Permits(openapi3.TypeArray) {
+			return "", errors.New("property " + propName + " of object parameter " + modelName + " must be a scalar")
+		}
+	}
+
+	if schema.Ref != "" {
+		typeName, importPath := g.ParseRefTypeName(schema.Ref)
+		if importPath != "" {
+			g.AddSchemasImport(importPath)
+		}
+		return typeName, nil
+	}
+	if !g.SchemasFile.generatedModels[modelName] {
+		g.SchemasFile.generatedModels[modelName] = true
+		if err := g.ProcessObjectSchema(modelName, schema); err != nil {
+			return "", errors.Wrap(err, op)
+		}
+	}
+
+	return modelName, nil
+}
+
+// parseObjectQueryParam emits a parse<Op><Param>QueryParam method collecting the
+// properties of an object parameter and returns the statements storing its result
+// in queryParams. The method returns nil when none of the properties is present.
+func (g *Generator) parseObjectQueryParam(baseName string, param *openapi3.Parameter) ([]ast.Stmt, error) {
+	fieldName := FormatGoLikeIdentifier(param.Name)
+	varName := GoIdentLowercase(fieldName)
+
+	style, explode := paramSerialization(param)
+	var queryKey func(propName string) string
+	switch {
+	case style == openapi3.SerializationDeepObject:
+		// filter[status]=open&filter[owner]=me
+		queryKey = func(propName string) string { return param.Name + "[" + propName + "]" }
+	case style == openapi3.SerializationForm && explode:
+		// status=open&owner=me
+		queryKey = func(propName string) string { return propName }
+	default:
+		return nil, errors.New("unsupported style " + style + " for object query parameter " + param.Name)
+	}
+
+	schema := param.Schema
+	typeExpr := ast.Expr(Sel(I(g.GetCurrentModelsPackage()), baseName+"QueryParams"+fieldName))
+	if schema.Ref != "" {
+		typeName, importPath := g.ParseRefTypeName(schema.Ref)
+		typeExpr = Sel(I(g.GetCurrentModelsPackage()), typeName)
+		if importPath != "" {
+			g.AddHandlersImport(importPath)
+		}
+		if refIsExternal(schema.Ref) {
+			typeExpr = I(typeName)
+		}
+	}
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	requiredProps := make(map[string]bool)
+	for _, propName := range schema.Value.Required {
+		requiredProps[propName] = true
+	}
+	propNames := make([]string, 0, len(schema.Value.Properties))
+	for propName := range schema.Value.Properties {
+		propNames = append(propNames, propName)
+	}
+	sort.Strings(propNames)
+
+	bodyList := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{I(varName)},
+						Type:  typeExpr,
+					},
+				},
+			},
+		},
+	}
+	var absent ast.Expr
+	for _, propName := range propNames {
+		propVar := varName + FormatGoLikeIdentifier(propName)
+		bodyList = append(bodyList, &ast.AssignStmt{
+			Lhs: []ast.Expr{I(propVar)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: Sel(&ast.CallExpr{
+					Fun:  Sel(Sel(I("r"), "URL"), "Query"),
+					Args: []ast.Expr{},
+				}, "Get"),
+				Args: []ast.Expr{Str(queryKey(propName))},
+			}},
+		})
+		if absent == nil {
+			absent = Eq(I(propVar), Str(""))
+		} else {
+			absent = &ast.BinaryExpr{X: absent, Op: token.LAND, Y: Eq(I(propVar), Str(""))}
+		}
+	}
+	bodyList = append(bodyList, &ast.IfStmt{
+		Cond: absent,
+		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("nil"))}},
+	})
+
+	for _, propName := range propNames {
+		propSchema := schema.Value.Properties[propName]
+		propVar := varName + FormatGoLikeIdentifier(propName)
+		propField := FormatGoLikeIdentifier(propName)
+		required := requiredProps[propName]
+
+		var stmts []ast.Stmt
+		if propSchema.Value.Type.Permits(openapi3.TypeString) && isParamTypedFormat(propSchema.Value.Format) {
+			// object models share the JSON body mapping, which keeps dates and
+			// uuids as strings
+			stmts = []ast.Stmt{g.assignRawField(varName, propVar, propField, required)}
+		} else {
+			var err error
+			stmts, err = g.assignParamField(varName, propVar, propField, propSchema, required)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !required {
+			bodyList = append(bodyList, &ast.IfStmt{
+				Cond: Ne(I(propVar), Str("")),
+				Body: &ast.BlockStmt{List: stmts},
+			})
+			continue
+		}
+		bodyList = append(bodyList, &ast.IfStmt{
+			Cond: Eq(I(propVar), Str("")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"),
+				requiredParamError(queryKey(propName)+" query param is required"),
+			)}},
+		})
+		bodyList = append(bodyList, stmts...)
+	}
+	bodyList = append(bodyList, Ret2(Amp(I(varName)), I("nil")))
+
+	methodName := "parse" + baseName + fieldName + "QueryParam"
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func(methodName,
+		Field("h", Star(I("Handler")), ""),
+		[]*ast.Field{
+			Field("r", Star(Sel(I("http"), "Request")), ""),
+		},
+		[]*ast.Field{
+			Field("", Star(typeExpr), ""),
+			Field("", I("error"), ""),
+		},
+		bodyList,
+	))
+
+	result := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I(varName), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("h"), methodName),
+				Args: []ast.Expr{I("r")},
+			}},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
+		},
+	}
+	if !param.Required {
+		return append(result, &ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I("queryParams"), fieldName)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{I(varName)},
+		}), nil
+	}
+
+	var value ast.Expr = Star(I(varName))
+	if g.HandlersFile.requiredFieldsArePointers {
+		value = I(varName)
+	}
+	return append(result,
+		&ast.IfStmt{
+			Cond: Eq(I(varName), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"),
+				requiredParamError(param.Name+" query param is required"),
+			)}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I("queryParams"), fieldName)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{value},
+		},
+	), nil
+}
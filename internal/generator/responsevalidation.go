@@ -0,0 +1,204 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const responseValidationSrc = `package _
+
+// ResponseValidationError is returned for a response that does not match the spec.
+type ResponseValidationError struct {
+	Operation  string
+	StatusCode int
+	Err        *ValidationError
+}
+
+func (e *ResponseValidationError) Error() string {
+	return fmt.Sprintf("%s: invalid %d response: %s", e.Operation, e.StatusCode, e.Err.Error())
+}
+
+func (e *ResponseValidationError) Unwrap() error {
+	return e.Err
+}
+
+// WithResponseValidation checks every response against the spec before it is
+// written; a mismatch is reported to the error handler as 500.
+func WithResponseValidation() Option {
+	return func(h *Handler) { h.validateResponses = true }
+}
+`
+
+func (g *Generator) AddResponseValidationDecls() {
+	g.AddHandlersImport("fmt")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", responseValidationSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// responseBodyChecks returns the layer-1 check of the marshaled body bodyJSON
+// and the layer-2 check of field, the body of a response of schema, with the
+// import path of the package declaring the layer-1 validator. A check the
+// schema does not need is nil.
+func (g *Generator) responseBodyChecks(
+	typeName string, ref string, schema *openapi3.SchemaRef, field ast.Expr, validator ast.Expr,
+) (ast.Expr, ast.Expr, string) {
+	var layer1, layer2 ast.Expr
+	var importPath string
+	if ref != "" {
+		typeName, _ = g.ParseRefTypeName(ref)
+	}
+	if g.hasValidateFunc(schema.Value) || len(schema.Value.AllOf) > 0 {
+		var validateFunc ast.Expr
+		validateFunc, importPath = g.validateFuncRef(typeName, ref)
+		layer1 = &ast.CallExpr{Fun: validateFunc, Args: []ast.Expr{I("bodyJSON")}}
+	}
+
+	value := schema.Value
+	switch {
+	case len(value.AllOf) > 0 || len(value.OneOf) > 0 || len(value.AnyOf) > 0 ||
+		value.Type.Permits(openapi3.TypeObject) && !isMapSchema(value):
+		layer2 = &ast.CallExpr{
+			Fun:  Sel(validator, "Struct"),
+			Args: []ast.Expr{field},
+		}
+	default:
+		// validator.Struct rejects maps, slices and scalars; they are checked with their tags
+		if tags := GetSchemaValidators(schema); len(tags) > 0 {
+			layer2 = &ast.CallExpr{
+				Fun:  Sel(validator, "Var"),
+				Args: []ast.Expr{field, Str(strings.Join(tags, ","))},
+			}
+		}
+	}
+
+	return layer1, layer2, importPath
+}
+
+// AddValidateResponseCodeMethod adds validate<Op><code>Response, checking the
+// body and headers of the response against the spec, and reports whether the
+// response has anything to check.
+func (g *Generator) AddValidateResponseCodeMethod(baseName string, code string, response *openapi3.ResponseRef) bool {
+	var body []ast.Stmt
+	contentTypes := sortedKeys(response.Value.Content)
+	for _, contentType := range contentTypes {
+		content := response.Value.Content[contentType]
+		if content.Schema == nil || isBinaryContent(contentType, content) {
+			// binary bodies are streamed unchecked
+			continue
+		}
+		fieldName := responseBodyField(contentType, contentTypes)
+		var field ast.Expr = Sel(I("resp"), fieldName)
+		if len(contentTypes) > 1 {
+			field = Star(field)
+		}
+		layer1, layer2, importPath := g.responseBodyChecks(baseName+"Response"+code+fieldName,
+			resolveSchemaRefAgainstResponse(response.Ref, content.Schema.Ref), content.Schema, field, Sel(I("h"), "validator"),
+		)
+		if importPath != "" {
+			g.AddHandlersImport(importPath)
+		}
+		var checks []ast.Stmt
+		switch {
+		case layer1 != nil:
+			g.AddHandlersImport("encoding/json")
+			checks = append(checks, &ast.AssignStmt{
+				Lhs: []ast.Expr{I("bodyJSON"), I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("json"), "Marshal"), Args: []ast.Expr{field}}},
+			}, ifNoErr(layer1))
+			if layer2 != nil {
+				checks = append(checks, ifNoErr(layer2))
+			}
+		case layer2 != nil:
+			checks = append(checks, &ast.AssignStmt{
+				Lhs: []ast.Expr{I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{layer2},
+			})
+		default:
+			continue
+		}
+		checks = append(checks, mergeFieldErrors(Str("body"), I("err")))
+		if len(contentTypes) > 1 {
+			// only the bodies offered are checked
+			checks = []ast.Stmt{&ast.IfStmt{
+				Cond: Ne(Sel(I("resp"), fieldName), I("nil")),
+				Body: &ast.BlockStmt{List: checks},
+			}}
+		}
+		body = append(body, checks...)
+	}
+	if len(response.Value.Headers) > 0 {
+		body = append(body, mergeFieldErrors(Str("header"), &ast.CallExpr{
+			Fun:  Sel(Sel(I("h"), "validator"), "Struct"),
+			Args: []ast.Expr{Sel(I("resp"), "Headers")},
+		}))
+	}
+	if len(body) == 0 {
+		return false
+	}
+
+	body = append([]ast.Stmt{g.errsDecl()}, body...)
+	body = append(body,
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{Sel(I("errs"), "Errors")}},
+				Op: token.GTR,
+				Y:  intLit("0"),
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(Amp(&ast.CompositeLit{
+				Type: I("ResponseValidationError"),
+				Elts: []ast.Expr{
+					&ast.KeyValueExpr{Key: I("Operation"), Value: Str(baseName)},
+					&ast.KeyValueExpr{Key: I("StatusCode"), Value: intLit(code)},
+					&ast.KeyValueExpr{Key: I("Err"), Value: Amp(I("errs"))},
+				},
+			}))}},
+		},
+		Ret1(I("nil")),
+	)
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func(
+		"validate"+baseName+code+"Response",
+		Field("h", Star(I("Handler")), ""),
+		[]*ast.Field{
+			Field("resp", Star(Sel(I(g.GetCurrentModelsPackage()), baseName+"Response"+code)), ""),
+		},
+		[]*ast.Field{
+			Field("", I("error"), ""),
+		},
+		body,
+	))
+
+	return true
+}
+
+// validateResponseStmt reports the failure of validate<Op><code>Response to the
+// error handler when the handler validates its responses.
+func validateResponseStmt(baseName string, code string) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: Sel(I("h"), "validateResponses"),
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  Sel(I("h"), "validate"+baseName+code+"Response"),
+					Args: []ast.Expr{Sel(I("response"), "Response"+code)},
+				}},
+			},
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				writeStandardErrorCall("StatusInternalServerError", &ast.CallExpr{Fun: Sel(I("err"), "Error")}),
+				Ret(),
+			}},
+		}}},
+	}
+}
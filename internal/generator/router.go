@@ -0,0 +1,211 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+	"github.com/sintoniastrategy/validgo-gen/internal/generator/options"
+)
+
+// routerType returns the type of the router AddRoutes registers the routes on.
+func (g *Generator) routerType() ast.Expr {
+	if g.Opts.Router == options.RouterServeMux {
+		return Star(Sel(I("http"), "ServeMux"))
+	}
+	g.AddHandlersImport("github.com/go-chi/chi/v5")
+
+	return Sel(I("chi"), "Router")
+}
+
+// routeStmt registers the handle method of the operation on the router.
+func (g *Generator) routeStmt(baseName string, method string, pathName string) (ast.Stmt, error) {
+	handler := operationHandlerExpr(baseName)
+	if g.Opts.Router != options.RouterServeMux {
+		return &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(I("router"), "Method"),
+			Args: []ast.Expr{Sel(I("http"), "Method"+method), Str(pathName), handler},
+		}}, nil
+	}
+
+	pattern, err := serveMuxPattern(pathName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ExprStmt{X: &ast.CallExpr{
+		Fun:  Sel(I("router"), "Handle"),
+		Args: []ast.Expr{Str(strings.ToUpper(method) + " " + pattern), handler},
+	}}, nil
+}
+
+// pathValueExpr reads the path parameter name of the request r.
+func (g *Generator) pathValueExpr(name string) ast.Expr {
+	if g.Opts.Router == options.RouterServeMux {
+		return &ast.CallExpr{
+			Fun:  Sel(I("r"), "PathValue"),
+			Args: []ast.Expr{Str(serveMuxWildcard(name))},
+		}
+	}
+
+	return &ast.CallExpr{
+		Fun:  Sel(I("chi"), "URLParam"),
+		Args: []ast.Expr{I("r"), Str(name)},
+	}
+}
+
+// serveMuxPattern converts an OpenAPI path into an http.ServeMux pattern. The
+// wildcards of ServeMux take a whole segment and match exactly one, unless the
+// pattern ends with a slash, which is anchored with {$}.
+func serveMuxPattern(pathName string) (string, error) {
+	segments := strings.Split(pathName, "/")
+	for i, segment := range segments {
+		if !strings.ContainsAny(segment, "{}") {
+			continue
+		}
+		name, ok := strings.CutPrefix(segment, "{")
+		name, closed := strings.CutSuffix(name, "}")
+		if !ok || !closed || strings.ContainsAny(name, "{}") {
+			return "", errors.Errorf("path %s: segment %s is not a single parameter, which http.ServeMux does not support", pathName, segment)
+		}
+		segments[i] = "{" + serveMuxWildcard(name) + "}"
+	}
+	pattern := strings.Join(segments, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "{$}"
+	}
+
+	return pattern, nil
+}
+
+// serveMuxWildcard returns the wildcard name of the path parameter name:
+// ServeMux only accepts Go identifiers.
+func serveMuxWildcard(name string) string {
+	wildcard := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if wildcard == "" || unicode.IsDigit(rune(wildcard[0])) {
+		wildcard = "_" + wildcard
+	}
+
+	return wildcard
+}
+
+// basePaths returns the distinct base paths of the servers of the spec, an
+// empty string standing for the root.
+func basePaths(servers openapi3.Servers) ([]string, error) {
+	if len(servers) == 0 {
+		return []string{""}, nil
+	}
+
+	seen := make(map[string]bool, len(servers))
+	paths := make([]string, 0, len(servers))
+	for _, server := range servers {
+		basePath, err := server.BasePath()
+		if err != nil {
+			return nil, errors.Wrap(err, "server "+server.URL)
+		}
+		basePath = strings.TrimSuffix(basePath, "/")
+		if seen[basePath] {
+			continue
+		}
+		seen[basePath] = true
+		paths = append(paths, basePath)
+	}
+
+	return paths, nil
+}
+
+// AddOperationHandlerDecl adds <Op>HTTPHandler, exposing the handle method of
+// the operation, wrapped in its middleware, as an http.Handler.
+func (g *Generator) AddOperationHandlerDecl(baseName string) {
+	g.HandlersFile.routerDecls = append(g.HandlersFile.routerDecls, Func(baseName+"HTTPHandler",
+		Field("h", Star(I("Handler")), ""),
+		nil,
+		[]*ast.Field{Field("", Sel(I("http"), "Handler"), "")},
+		[]ast.Stmt{Ret1(operationHandlerExpr(baseName))},
+	))
+}
+
+// routerDecls returns Router, building a router serving every operation under
+// the base paths of the servers, and ServeHTTP, serving the router NewHandler
+// builds.
+func (g *Generator) routerDecls() []ast.Decl {
+	addRoutes := func(router ast.Expr) ast.Stmt {
+		return &ast.ExprStmt{X: &ast.CallExpr{Fun: Sel(I("h"), "AddRoutes"), Args: []ast.Expr{router}}}
+	}
+	newRouter := &ast.CallExpr{Fun: Sel(I("chi"), "NewRouter")}
+	if g.Opts.Router == options.RouterServeMux {
+		newRouter = &ast.CallExpr{Fun: Sel(I("http"), "NewServeMux")}
+	}
+
+	body := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{I("router")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{newRouter},
+	}}
+	basePaths := g.HandlersFile.basePaths
+	switch {
+	case len(basePaths) == 1 && basePaths[0] == "":
+		body = append(body, addRoutes(I("router")))
+	case g.Opts.Router == options.RouterServeMux:
+		// the routes are matched on the path without the base path
+		body = append(body,
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("routes")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("http"), "NewServeMux")}},
+			},
+			addRoutes(I("routes")),
+		)
+		for _, basePath := range basePaths {
+			var route ast.Expr = I("routes")
+			if basePath != "" {
+				route = &ast.CallExpr{Fun: Sel(I("http"), "StripPrefix"), Args: []ast.Expr{Str(basePath), route}}
+			}
+			body = append(body, &ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  Sel(I("router"), "Handle"),
+				Args: []ast.Expr{Str(basePath + "/"), route},
+			}})
+		}
+	default:
+		for _, basePath := range basePaths {
+			if basePath == "" {
+				body = append(body, addRoutes(I("router")))
+				continue
+			}
+			body = append(body, &ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  Sel(I("router"), "Route"),
+				Args: []ast.Expr{Str(basePath), Sel(I("h"), "AddRoutes")},
+			}})
+		}
+	}
+	body = append(body, Ret1(I("router")))
+
+	return []ast.Decl{
+		Func("Router",
+			Field("h", Star(I("Handler")), ""),
+			nil,
+			[]*ast.Field{Field("", Sel(I("http"), "Handler"), "")},
+			body,
+		),
+		Func("ServeHTTP",
+			Field("h", Star(I("Handler")), ""),
+			[]*ast.Field{
+				Field("w", Sel(I("http"), "ResponseWriter"), ""),
+				Field("r", Star(Sel(I("http"), "Request")), ""),
+			},
+			nil,
+			[]ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  Sel(Sel(I("h"), "router"), "ServeHTTP"),
+				Args: []ast.Expr{I("w"), I("r")},
+			}}},
+		),
+	}
+}
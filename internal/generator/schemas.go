@@ -17,7 +17,13 @@ type SchemasFile struct {
 	requiredFieldsArePointers bool
 	packageImports            []string
 	decls                     []*ast.GenDecl
+	funcDecls                 []ast.Decl
 	generatedModels           map[string]bool
+	hasDecodeVariant          bool
+	hasMultipartFile          bool
+	hasBinaryBody             bool
+
+	hasAdditionalPropertiesHelpers bool
 }
 
 type SchemaStruct struct {
@@ -101,6 +107,7 @@ func (g *Generator) WriteSchemasToOutput(output io.Writer) error {
 	for _, decl := range g.SchemasFile.decls {
 		file.Decls = append(file.Decls, decl)
 	}
+	file.Decls = append(file.Decls, g.SchemasFile.funcDecls...)
 
 	err = format.Node(output, token.NewFileSet(), file)
 	if err != nil {
@@ -192,6 +199,21 @@ func (g *Generator) AddSliceAlias(name string, typeName string) {
 	})
 }
 
+func (g *Generator) AddMapAlias(name string, typeName string) {
+	g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(name),
+				Type: &ast.MapType{
+					Key:   ast.NewIdent("string"),
+					Value: ast.NewIdent(typeName),
+				},
+			},
+		},
+	})
+}
+
 func (g *Generator) AddParamsModel(baseName string, paramType string, params openapi3.Parameters) error {
 	const op = "generator.AddParamsModel"
 	fields := make([]SchemaField, 0, len(params))
@@ -200,21 +222,36 @@ func (g *Generator) AddParamsModel(baseName string, paramType string, params ope
 		paramSchemaType := param.Value.Schema.Value.Type
 		if !paramSchemaType.Permits(openapi3.TypeString) &&
 			!paramSchemaType.Permits(openapi3.TypeInteger) &&
-			!paramSchemaType.Permits(openapi3.TypeNumber) {
-			return errors.New("only string, integer, and number parameters are supported for " + paramType + " parameters")
+			!paramSchemaType.Permits(openapi3.TypeNumber) &&
+			!paramSchemaType.Permits(openapi3.TypeBoolean) &&
+			!(paramType == "QueryParams" && paramSchemaType.Permits(openapi3.TypeArray)) &&
+			!(paramType == "QueryParams" && paramSchemaType.Permits(openapi3.TypeObject)) {
+			return errors.New("only string, integer, number, and boolean parameters are supported for " + paramType + " parameters")
 		}
 		var jsonTags []string
 		var validateTags []string
 		jsonTags = append(jsonTags, param.Value.Name)
 		if param.Value.Required {
-			validateTags = append(validateTags, "required")
+			// presence is checked by the parser; on a value field "required" would
+			// reject a legitimate zero, like 0, false or an object whose present
+			// properties all hold zero values, so it only checks pointers
+			if g.SchemasFile.requiredFieldsArePointers &&
+				!paramSchemaType.Permits(openapi3.TypeBoolean) && !paramSchemaType.Permits(openapi3.TypeObject) {
+				validateTags = append(validateTags, "required")
+			}
 		} else {
 			jsonTags = append(jsonTags, "omitempty")
 			validateTags = append(validateTags, "omitempty")
 		}
 
 		validateTags = append(validateTags, GetSchemaValidators(param.Value.Schema)...)
-		fieldType, err := g.GetFieldTypeFromSchema(name, "", param.Value.Schema)
+		var fieldType string
+		var err error
+		if paramSchemaType.Permits(openapi3.TypeObject) {
+			fieldType, err = g.AddObjectParamModel(baseName+paramType+name, param.Value.Schema)
+		} else {
+			fieldType, err = g.GetParamFieldType(name, param.Value.Schema)
+		}
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
@@ -320,15 +357,63 @@ func (g *Generator) GetStringType(format string) string {
 		g.AddSchemasImport("github.com/shopspring/decimal")
 		return "decimal.Decimal"
 	}
+	if format == "binary" {
+		g.AddMultipartFileModel()
+		return "MultipartFile"
+	}
 
 	return "string"
 }
 
+// isParamTypedFormat reports whether strings of the format are kept as plain
+// strings in body models but parsed into their type for parameters.
+func isParamTypedFormat(format string) bool {
+	return format == "date" || format == "uuid"
+}
+
+// GetParamFieldType returns the Go type of a parameter field. It matches
+// GetFieldTypeFromSchema except for `format: date` and `format: uuid`, which are
+// kept as plain strings in JSON bodies but parsed into time.Time and uuid.UUID
+// for parameters: the parser of a parameter checks the full-date layout itself,
+// while encoding/json decodes a time.Time only from an RFC 3339 date-time.
+func (g *Generator) GetParamFieldType(name string, schema *openapi3.SchemaRef) (string, error) {
+	if schema.Ref == "" && schema.Value.Type.Permits(openapi3.TypeString) {
+		switch schema.Value.Format {
+		case "date":
+			g.AddSchemasImport("time")
+			return "time.Time", nil
+		case "uuid":
+			g.AddSchemasImport("github.com/google/uuid")
+			return "uuid.UUID", nil
+		}
+	}
+	if schema.Ref == "" && schema.Value.Type.Permits(openapi3.TypeArray) {
+		items := schema.Value.Items
+		if items == nil || items.Ref != "" || items.Value.Type.Permits(openapi3.TypeArray) ||
+			items.Value.Type.Permits(openapi3.TypeObject) {
+			return "", errors.New("array parameter " + name + " must declare scalar items inline")
+		}
+		elemType, err := g.GetParamFieldType(name, items)
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + elemType, nil
+	}
+
+	return g.GetFieldTypeFromSchema(name, "", schema)
+}
+
 func (g *Generator) GetDerefFieldTypeFromSchema(modelName string, fieldName string,
 	fieldSchema *openapi3.SchemaRef,
 ) (string, error) {
 	var fieldType string
 	switch {
+	case len(fieldSchema.Value.AllOf) > 0 || len(fieldSchema.Value.OneOf) > 0 || len(fieldSchema.Value.AnyOf) > 0:
+		// composed schemas become structs even when no type is declared
+		if fieldSchema.Ref == "" {
+			fieldType = modelName + FormatGoLikeIdentifier(fieldName)
+		}
 	case fieldSchema.Value.Type.Permits(openapi3.TypeString):
 		fieldType = g.GetStringType(fieldSchema.Value.Format)
 	case fieldSchema.Value.Type.Permits(openapi3.TypeInteger):
@@ -376,6 +461,24 @@ func (g *Generator) ProcessObjectSchema(modelName string, schema *openapi3.Schem
 		Fields: []SchemaField{},
 	}
 
+	additional := schema.Value.AdditionalProperties.Schema
+	var additionalType string
+	switch {
+	case hasUntypedAdditionalProperties(schema.Value):
+		additional = nil
+		additionalType = "any"
+	case additional != nil:
+		var err error
+		additionalType, err = g.ProcessAdditionalProperties(modelName, additional)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+	if additionalType != "" && len(schema.Value.Properties) == 0 {
+		g.AddMapAlias(modelName, additionalType)
+		return nil
+	}
+
 	requiredFields := make(map[string]bool)
 	for _, fieldName := range schema.Value.Required {
 		requiredFields[fieldName] = true
@@ -437,7 +540,20 @@ func (g *Generator) ProcessObjectSchema(modelName string, schema *openapi3.Schem
 		}
 		model.Fields = append(model.Fields, field)
 	}
+	if additionalType != "" {
+		// unknown properties are kept in a catch-all map
+		model.Fields = append(model.Fields, SchemaField{
+			Name:        "AdditionalProperties",
+			Type:        "map[string]" + additionalType,
+			TagJSON:     []string{"-"},
+			TagValidate: append([]string{"dive"}, GetSchemaValidators(additional)...),
+			Required:    true,
+		})
+	}
 	g.AddSchema(model)
+	if additionalType != "" {
+		g.AddAdditionalPropertiesJSON(modelName, additionalType, keys)
+	}
 
 	return nil
 }
@@ -502,6 +618,24 @@ func (g *Generator) ProcessSchema(modelName string, schema *openapi3.SchemaRef)
 	}
 	g.SchemasFile.generatedModels[modelName] = true
 	const op = "generator.ProcessSchema"
+	if len(schema.Value.AllOf) > 0 {
+		merged, err := g.mergeAllOf(modelName, schema.Value, "")
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if g.HandlersFile.strictSchemas[schema.Value] {
+			g.HandlersFile.strictSchemas[merged] = true
+		}
+		schema = &openapi3.SchemaRef{Ref: schema.Ref, Value: merged}
+	}
+	if len(schema.Value.OneOf) > 0 || len(schema.Value.AnyOf) > 0 {
+		err := g.ProcessSumSchema(modelName, schema)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		return nil
+	}
 	switch {
 	case schema.Value.Type.Permits(openapi3.TypeObject):
 		err := g.ProcessObjectSchema(modelName, schema)
@@ -519,13 +653,10 @@ func (g *Generator) ProcessSchema(modelName string, schema *openapi3.SchemaRef)
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
-		if schema.Value.Items != nil {
-			itemsType := g.getMostNestedArrayItemType(schema.Value.Items)
-			if itemsType != nil && itemsType.Permits(openapi3.TypeObject) {
-				err = g.AddArrayValidate(modelName, schema)
-				if err != nil {
-					return errors.Wrap(err, op)
-				}
+		if g.hasValidateFunc(schema.Value) {
+			err = g.AddArrayValidate(modelName, schema)
+			if err != nil {
+				return errors.Wrap(err, op)
 			}
 		}
 
@@ -563,17 +694,17 @@ func (g *Generator) ProcessSchema(modelName string, schema *openapi3.SchemaRef)
 	return errors.Errorf("unsupported schema type %s for model %s", schema.Value.Type, modelName)
 }
 
-func (g *Generator) getMostNestedArrayItemType(schema *openapi3.SchemaRef) *openapi3.Types {
-	for schema != nil && schema.Value.Type.Permits(openapi3.TypeArray) {
+// getMostNestedArrayItems returns the items of the innermost array of schema,
+// nil when an array declares none. A oneOf/anyOf has no type, which permits
+// array: the walk follows items rather than the type.
+func (g *Generator) getMostNestedArrayItems(schema *openapi3.SchemaRef) *openapi3.SchemaRef {
+	for schema != nil && schema.Value.Type.Permits(openapi3.TypeArray) && schema.Value.Items != nil {
 		schema = schema.Value.Items
 	}
-	if schema == nil {
-		return nil
-	}
-	return schema.Value.Type
+	return schema
 }
 
-func (g *Generator) GenerateRequestModel(baseName string, contentType string, pathParams openapi3.Parameters,
+func (g *Generator) GenerateRequestModel(baseName string, contentTypes []string, pathParams openapi3.Parameters,
 	queryParams openapi3.Parameters, headers openapi3.Parameters, cookieParams openapi3.Parameters,
 	body *openapi3.RequestBodyRef,
 ) {
@@ -617,27 +748,47 @@ func (g *Generator) GenerateRequestModel(baseName string, contentType string, pa
 			Required:    true,
 		})
 	}
-	if body != nil && body.Value != nil {
-		content, ok := body.Value.Content[contentType]
-		if ok && content.Schema != nil {
-			typeName := baseName + "RequestBody"
-
-			if content.Schema.Ref != "" {
-				var importPath string
-				typeName, importPath = g.ParseRefTypeName(content.Schema.Ref)
-				if importPath != "" {
-					g.AddSchemasImport(importPath)
-				}
+	if len(contentTypes) > 1 {
+		// the negotiated media type and the body read for it, one field per
+		// content type
+		model.Fields = append(model.Fields, SchemaField{
+			Name:        "ContentType",
+			Type:        "string",
+			TagJSON:     []string{},
+			TagValidate: []string{},
+			Required:    true,
+		})
+	}
+	for _, contentType := range contentTypes {
+		content := body.Value.Content[contentType]
+		if content.Schema == nil {
+			continue
+		}
+		bodyName := requestBodyName(baseName, contentType, contentTypes)
+		typeName := bodyName + "RequestBody"
+		if isBinaryContent(contentType, content) {
+			g.AddBinaryBodyModel()
+			typeName = "BinaryBody"
+		} else if content.Schema.Ref != "" {
+			var importPath string
+			typeName, importPath = g.ParseRefTypeName(content.Schema.Ref)
+			if importPath != "" {
+				g.AddSchemasImport(importPath)
 			}
+		}
 
-			model.Fields = append(model.Fields, SchemaField{
-				Name:        "Body",
-				Type:        typeName,
-				TagJSON:     []string{},
-				TagValidate: []string{},
-				Required:    body.Value.Required,
-			})
+		field := SchemaField{
+			Name:        "Body",
+			Type:        typeName,
+			TagJSON:     []string{},
+			TagValidate: []string{},
+			Required:    body.Value.Required,
 		}
+		if len(contentTypes) > 1 {
+			field.Name = bodyVariant(contentType) + "Body"
+			field.Required = false
+		}
+		model.Fields = append(model.Fields, field)
 	}
 
 	g.AddSchema(model)
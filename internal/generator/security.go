@@ -0,0 +1,327 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const securitySrc = `package _
+
+// SecurityScheme identifies a security scheme by its name in the spec.
+type SecurityScheme string
+
+type principalsKey struct{}
+
+// PrincipalFromContext returns the principal the authenticator of scheme
+// returned for the request of ctx.
+func PrincipalFromContext(ctx context.Context, scheme SecurityScheme) (any, bool) {
+	principals, _ := ctx.Value(principalsKey{}).(map[SecurityScheme]any)
+	principal, ok := principals[scheme]
+	return principal, ok
+}
+
+var errNoCredentials = errors.New("no credentials")
+
+// SecurityError is returned by an authenticator to answer the request with
+// StatusCode, like 403 for credentials lacking a permission; any other error
+// is answered 401.
+type SecurityError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *SecurityError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.StatusCode)
+	}
+	return e.Err.Error()
+}
+
+func (e *SecurityError) Unwrap() error {
+	return e.Err
+}
+
+type securityCheck struct {
+	scheme       SecurityScheme
+	scopes       []string
+	authenticate func(r *http.Request, scopes []string) (any, error)
+}
+
+// authenticate returns r with the principals of the first security
+// requirement every check of passes. An empty requirement lets the request
+// through without credentials, unless it brings some that fail.
+func authenticate(r *http.Request, requirements ...[]securityCheck) (*http.Request, error) {
+	var errs []error
+	anonymous, supplied := false, false
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			anonymous = true
+			continue
+		}
+		principals := make(map[SecurityScheme]any, len(requirement))
+		var err error
+		for _, check := range requirement {
+			var principal any
+			principal, err = check.authenticate(r, check.scopes)
+			if !errors.Is(err, errNoCredentials) {
+				supplied = true
+			}
+			if err != nil {
+				break
+			}
+			principals[check.scheme] = principal
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return r.WithContext(context.WithValue(r.Context(), principalsKey{}, principals)), nil
+	}
+	if anonymous && !supplied {
+		return r, nil
+	}
+	return r, errors.Join(errs...)
+}
+
+// handleSecurityError answers a request failing the security requirements with
+// the status of the first *SecurityError of err, 401 otherwise.
+func (h *Handler) handleSecurityError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusUnauthorized
+	var securityError *SecurityError
+	if errors.As(err, &securityError) {
+		status = securityError.StatusCode
+	}
+	h.errorHandler(w, r, status, http.StatusText(status))
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", errNoCredentials
+	}
+	return token, nil
+}
+`
+
+// securityScheme is a security scheme operations of the spec require.
+type securityScheme struct {
+	name   string
+	goName string
+	scheme *openapi3.SecurityScheme
+}
+
+// authenticatorField returns the name of the Handler field and constructor
+// argument holding the authenticator of the scheme.
+func (s securityScheme) authenticatorField() string {
+	return GoIdentLowercase(s.goName) + "Authenticator"
+}
+
+// credentials returns the parameters of the Authenticate method of the
+// scheme, the statements extracting them from the request r, and the
+// arguments passing them on.
+func (s securityScheme) credentials() (string, string, string, error) {
+	switch {
+	case s.scheme.Type == "http" && strings.EqualFold(s.scheme.Scheme, "bearer"):
+		return "token string", `token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}`, "token", nil
+	case s.scheme.Type == "http" && strings.EqualFold(s.scheme.Scheme, "basic"):
+		return "username string, password string", `username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, errNoCredentials
+	}`, "username, password", nil
+	case s.scheme.Type == "oauth2" || s.scheme.Type == "openIdConnect":
+		return "token string, scopes []string", `token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}`, "token, scopes", nil
+	case s.scheme.Type == "apiKey":
+		var extract string
+		switch s.scheme.In {
+		case "header":
+			extract = "key := r.Header.Get(" + strconv.Quote(s.scheme.Name) + ")"
+		case "query":
+			extract = "key := r.URL.Query().Get(" + strconv.Quote(s.scheme.Name) + ")"
+		case "cookie":
+			extract = `cookie, err := r.Cookie(` + strconv.Quote(s.scheme.Name) + `)
+	if err != nil {
+		return nil, errNoCredentials
+	}
+	key := cookie.Value`
+		default:
+			return "", "", "", errors.Errorf("security scheme %s: apiKey in %s is not supported", s.name, s.scheme.In)
+		}
+		return "key string", extract + `
+	if key == "" {
+		return nil, errNoCredentials
+	}`, "key", nil
+	default:
+		return "", "", "", errors.Errorf("security scheme %s: %s %s is not supported", s.name, s.scheme.Type, s.scheme.Scheme)
+	}
+}
+
+// AddSecurity generates authenticate<Op>Request, checking the security
+// requirements of the operation, or of the spec when the operation declares
+// none. An operation with an empty list of requirements is not checked.
+func (g *Generator) AddSecurity(baseName string, operation *openapi3.Operation) error {
+	const op = "generator.AddSecurity"
+	requirements := g.yaml.Security
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	var src strings.Builder
+	src.WriteString("package _\n\nfunc (h *Handler) authenticate" + baseName + "Request(r *http.Request) (*http.Request, error) {\n")
+	src.WriteString("\treturn authenticate(r,\n")
+	for _, requirement := range requirements {
+		src.WriteString("\t\t[]securityCheck{")
+		for i, name := range sortedKeys(requirement) {
+			scheme, err := g.useSecurityScheme(name)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if i > 0 {
+				src.WriteString(", ")
+			}
+			src.WriteString("{scheme: SecurityScheme" + scheme.goName)
+			if scopes := requirement[name]; len(scopes) > 0 {
+				quoted := make([]string, 0, len(scopes))
+				for _, scope := range scopes {
+					quoted = append(quoted, strconv.Quote(scope))
+				}
+				src.WriteString(", scopes: []string{" + strings.Join(quoted, ", ") + "}")
+			}
+			src.WriteString(", authenticate: h.authenticateWith" + scheme.goName + "}")
+		}
+		src.WriteString("},\n")
+	}
+	src.WriteString("\t)\n}\n")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", src.String(), 0)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	g.HandlersFile.secured[baseName] = true
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+
+	return nil
+}
+
+// useSecurityScheme returns the security scheme name of the components,
+// adding its authenticator to the handler the first time.
+func (g *Generator) useSecurityScheme(name string) (securityScheme, error) {
+	for _, scheme := range g.HandlersFile.securitySchemes {
+		if scheme.name == name {
+			return scheme, nil
+		}
+	}
+	if g.yaml.Components == nil || g.yaml.Components.SecuritySchemes[name] == nil {
+		return securityScheme{}, errors.Errorf("security scheme %s is not declared", name)
+	}
+	scheme := securityScheme{
+		name:   name,
+		goName: FormatGoLikeIdentifier(name),
+		scheme: g.yaml.Components.SecuritySchemes[name].Value,
+	}
+	_, _, _, err := scheme.credentials()
+	if err != nil {
+		return securityScheme{}, err
+	}
+	// authenticators follow the operations in NewHandler by scheme name
+	g.HandlersFile.securitySchemes = append(g.HandlersFile.securitySchemes, scheme)
+	slices.SortFunc(g.HandlersFile.securitySchemes, func(a, b securityScheme) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return scheme, nil
+}
+
+// AddSecurityDecls adds the authenticator interface, the SecurityScheme
+// constant and the credentials extraction of every scheme in use.
+func (g *Generator) AddSecurityDecls() {
+	if len(g.HandlersFile.securitySchemes) == 0 {
+		return
+	}
+	g.AddHandlersImport("context")
+	g.AddHandlersImport("strings")
+
+	var src strings.Builder
+	src.WriteString(securitySrc)
+	src.WriteString("\nconst (\n")
+	for _, scheme := range g.HandlersFile.securitySchemes {
+		src.WriteString("\tSecurityScheme" + scheme.goName + " SecurityScheme = " + strconv.Quote(scheme.name) + "\n")
+	}
+	src.WriteString(")\n")
+	for _, scheme := range g.HandlersFile.securitySchemes {
+		params, extract, args, _ := scheme.credentials()
+		src.WriteString("\ntype " + scheme.goName + "Authenticator interface {\n")
+		src.WriteString("\tAuthenticate" + scheme.goName + "(ctx context.Context, " + params + ") (any, error)\n}\n")
+		src.WriteString("\nfunc (h *Handler) authenticateWith" + scheme.goName + "(r *http.Request, scopes []string) (any, error) {\n")
+		src.WriteString("\t" + extract + "\n")
+		src.WriteString("\treturn h." + scheme.authenticatorField() + ".Authenticate" + scheme.goName + "(r.Context(), " + args + ")\n}\n")
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", src.String(), 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// addAuthenticatorDependencies adds the authenticator of every scheme in use
+// to the Handler struct and the constructor arguments, after the handlers of
+// the operations.
+func (g *Generator) addAuthenticatorDependencies() {
+	for _, scheme := range g.HandlersFile.securitySchemes {
+		field := scheme.authenticatorField()
+		g.HandlersFile.handlerDeclQAFieldList.List = append(g.HandlersFile.handlerDeclQAFieldList.List,
+			Field(field, I(scheme.goName+"Authenticator"), ""))
+		g.HandlersFile.handlerConstructorDeclQAArgs.List = append(g.HandlersFile.handlerConstructorDeclQAArgs.List,
+			Field(field, I(scheme.goName+"Authenticator"), ""))
+		g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts = append(
+			g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts,
+			&ast.KeyValueExpr{Key: I(field), Value: I(field)},
+		)
+	}
+}
+
+// authenticateStmts returns the statements answering a request failing the
+// security requirements of the operation; r is replaced by the request carrying
+// the principals.
+func (g *Generator) authenticateStmts(baseName string) []ast.Stmt {
+	if !g.HandlersFile.secured[baseName] {
+		return nil
+	}
+
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("r"), I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("h"), "authenticate"+baseName+"Request"),
+				Args: []ast.Expr{I("r")},
+			}},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun:  Sel(I("h"), "handleSecurityError"),
+					Args: []ast.Expr{I("w"), I("r"), I("err")},
+				}},
+				Ret(),
+			}},
+		},
+	}
+}
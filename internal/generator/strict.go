@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+// StrictExtension overrides the -strict flag for a schema and the inline
+// schemas nested in it.
+const StrictExtension = "x-strict"
+
+// schemaStrictness resolves whether schema rejects undeclared properties: the
+// x-strict extension wins over the strictness inherited from the parent.
+func schemaStrictness(where string, schema *openapi3.Schema, inherited bool) (bool, error) {
+	value, ok := schema.Extensions[StrictExtension]
+	if !ok {
+		return inherited, nil
+	}
+	strict, ok := value.(bool)
+	if !ok {
+		return false, errors.Errorf("%s of %s must be a boolean", StrictExtension, where)
+	}
+
+	return strict, nil
+}
+
+// rejectsUnknownFields reports whether the layer-1 validator of schema fails on
+// properties the schema does not declare.
+func (g *Generator) rejectsUnknownFields(schema *openapi3.Schema) bool {
+	additional := schema.AdditionalProperties
+	if additional.Has != nil {
+		return !*additional.Has
+	}
+
+	return additional.Schema == nil && g.HandlersFile.strictSchemas[schema]
+}
@@ -0,0 +1,524 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const decodeVariantSrc = `package _
+
+// decodeVariant decodes data into one variant of a oneOf/anyOf schema. An object
+// variant only matches when all of its required properties are present; strict
+// decoding also rejects properties the variant does not declare.
+func decodeVariant[T any](data []byte, strict bool, required ...string) (T, bool) {
+	var value T
+	if len(required) > 0 {
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return value, false
+		}
+		for _, key := range required {
+			if _, ok := obj[key]; !ok {
+				return value, false
+			}
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if decoder.Decode(&value) != nil {
+		return value, false
+	}
+	return value, true
+}
+`
+
+// sumVariant is a single oneOf/anyOf member of a sum type.
+type sumVariant struct {
+	typeName string   // Go type of the variant in the models package
+	required []string // required properties of an object variant
+	values   []string // discriminator values selecting the variant
+	validate bool     // whether Validate<typeName>JSON is generated for the variant
+}
+
+// ProcessSumSchema generates a sum type for a oneOf/anyOf schema: a struct
+// holding the chosen variant behind a marker interface, JSON methods picking
+// the variant and a layer-1 validator delegating to the chosen variant.
+func (g *Generator) ProcessSumSchema(modelName string, schema *openapi3.SchemaRef) error {
+	const op = "generator.ProcessSumSchema"
+	members, exclusive := schema.Value.OneOf, true
+	if len(members) == 0 {
+		members, exclusive = schema.Value.AnyOf, false
+	} else if len(schema.Value.AnyOf) > 0 {
+		return errors.Errorf("%s declares both oneOf and anyOf", modelName)
+	}
+
+	variants := make([]sumVariant, 0, len(members))
+	for i, member := range members {
+		variant, err := g.processSumVariant(modelName, i, member)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		variants = append(variants, variant)
+	}
+
+	discriminator := schema.Value.Discriminator
+	if discriminator != nil {
+		err := assignDiscriminatorValues(modelName, discriminator, members, variants)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	g.AddSumType(modelName, variants, discriminator, exclusive)
+	g.AddSumValidate(modelName, variants)
+
+	return nil
+}
+
+func (g *Generator) processSumVariant(modelName string, index int, member *openapi3.SchemaRef) (sumVariant, error) {
+	const op = "generator.processSumVariant"
+	variant := sumVariant{typeName: modelName + "Option" + strconv.Itoa(index+1)}
+	if member.Ref != "" {
+		// marker methods can only be declared in the package of the variant
+		if refIsExternal(member.Ref) {
+			return variant, errors.Errorf("variant %s of %s must be declared in the same file", member.Ref, modelName)
+		}
+		variant.typeName, _ = g.ParseRefTypeName(member.Ref)
+	} else {
+		err := g.ProcessSchema(variant.typeName, member)
+		if err != nil {
+			return variant, errors.Wrap(err, op)
+		}
+	}
+
+	value := member.Value
+	if len(value.AllOf) > 0 {
+		var err error
+		value, err = g.mergeAllOf(variant.typeName, value, "")
+		if err != nil {
+			return variant, errors.Wrap(err, op)
+		}
+	}
+	variant.required = value.Required
+	variant.validate = g.hasValidateFunc(value)
+
+	return variant, nil
+}
+
+// hasValidateFunc reports whether ProcessSchema emits Validate<Model>JSON for schema.
+func (g *Generator) hasValidateFunc(schema *openapi3.Schema) bool {
+	switch {
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		return true
+	case schema.Type.Permits(openapi3.TypeObject):
+		return true
+	case schema.Type.Permits(openapi3.TypeArray) && schema.Items != nil:
+		items := g.getMostNestedArrayItems(schema.Items)
+		return items != nil && g.hasValidateFunc(items.Value)
+	}
+
+	return false
+}
+
+// assignDiscriminatorValues collects the discriminator values of every variant:
+// the mapping keys pointing at it, or its schema name when none does.
+func assignDiscriminatorValues(modelName string, discriminator *openapi3.Discriminator,
+	members openapi3.SchemaRefs, variants []sumVariant,
+) error {
+	mapped := make(map[string]bool, len(discriminator.Mapping))
+	for i, member := range members {
+		if member.Ref == "" {
+			return errors.Errorf("variants of %s must be references to use a discriminator", modelName)
+		}
+		for _, key := range sortedKeys(discriminator.Mapping) {
+			target := discriminator.Mapping[key]
+			if target == member.Ref || target == variants[i].typeName {
+				variants[i].values = append(variants[i].values, key)
+				mapped[key] = true
+			}
+		}
+		if len(variants[i].values) == 0 {
+			variants[i].values = []string{variants[i].typeName}
+		}
+	}
+	for _, key := range sortedKeys(discriminator.Mapping) {
+		if !mapped[key] {
+			return errors.Errorf("discriminator mapping %s of %s does not point to one of its variants", key, modelName)
+		}
+	}
+
+	return nil
+}
+
+// AddSumType declares the sum type, the marker interface implemented by its
+// variants and the JSON methods of the sum type.
+func (g *Generator) AddSumType(modelName string, variants []sumVariant,
+	discriminator *openapi3.Discriminator, exclusive bool,
+) {
+	interfaceName := modelName + "Variant"
+	markerName := "is" + modelName
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: I(interfaceName),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: []*ast.Field{Field(markerName, &ast.FuncType{Params: &ast.FieldList{}}, "")},
+					},
+				},
+			},
+		},
+	}, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: I(modelName),
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{Field("Value", I(interfaceName), "`json:\"-\"`")},
+					},
+				},
+			},
+		},
+	})
+
+	for _, variant := range variants {
+		g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls,
+			Func(markerName, Field("", I(variant.typeName), ""), nil, nil, []ast.Stmt{}))
+	}
+
+	g.AddSchemasImport("encoding/json")
+	g.AddSchemasImport("github.com/go-faster/errors")
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, Func("MarshalJSON",
+		Field("s", I(modelName), ""),
+		nil,
+		[]*ast.Field{
+			Field("", &ast.ArrayType{Elt: I("byte")}, ""),
+			Field("", I("error"), ""),
+		},
+		[]ast.Stmt{
+			Ret1(&ast.CallExpr{
+				Fun:  Sel(I("json"), "Marshal"),
+				Args: []ast.Expr{Sel(I("s"), "Value")},
+			}),
+		},
+	))
+
+	body := []ast.Stmt{
+		&ast.IfStmt{
+			Cond: Eq(&ast.CallExpr{Fun: I("string"), Args: []ast.Expr{I("data")}}, Str("null")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("nil"))}},
+		},
+	}
+	if discriminator != nil {
+		body = append(body, g.discriminatedUnmarshalStmts(modelName, variants, discriminator.PropertyName)...)
+	} else {
+		body = append(body, g.variantMatchingStmts(modelName, interfaceName, variants, exclusive)...)
+	}
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, Func("UnmarshalJSON",
+		Field("s", Star(I(modelName)), ""),
+		[]*ast.Field{
+			Field("data", &ast.ArrayType{Elt: I("byte")}, ""),
+		},
+		[]*ast.Field{
+			Field("", I("error"), ""),
+		},
+		body,
+	))
+}
+
+// discriminatedUnmarshalStmts reads the discriminator property and decodes data
+// into the variant it selects.
+func (g *Generator) discriminatedUnmarshalStmts(modelName string, variants []sumVariant, propertyName string) []ast.Stmt {
+	clauses := make([]ast.Stmt, 0, len(variants)+1)
+	for _, variant := range variants {
+		values := make([]ast.Expr, 0, len(variant.values))
+		for _, value := range variant.values {
+			values = append(values, Str(value))
+		}
+		clauses = append(clauses, &ast.CaseClause{
+			List: values,
+			Body: []ast.Stmt{
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{I("value")},
+								Type:  I(variant.typeName),
+							},
+						},
+					},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{I("err")},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun:  Sel(I("json"), "Unmarshal"),
+							Args: []ast.Expr{I("data"), Amp(I("value"))},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: Ne(I("err"), I("nil")),
+					Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{Sel(I("s"), "Value")},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{I("value")},
+				},
+			},
+		})
+	}
+	clauses = append(clauses, &ast.CaseClause{
+		Body: []ast.Stmt{
+			Ret1(&ast.CallExpr{
+				Fun: Sel(I("errors"), "Errorf"),
+				Args: []ast.Expr{
+					Str(modelName + ": unknown " + propertyName + " %q"),
+					Sel(I("probe"), "Value"),
+				},
+			}),
+		},
+	})
+
+	return []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{I("probe")},
+						Type: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: []*ast.Field{
+									Field("Value", I("string"), "`json:\""+propertyName+"\"`"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun:  Sel(I("json"), "Unmarshal"),
+					Args: []ast.Expr{I("data"), Amp(I("probe"))},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+		},
+		&ast.SwitchStmt{
+			Tag:  Sel(I("probe"), "Value"),
+			Body: &ast.BlockStmt{List: clauses},
+		},
+		Ret1(I("nil")),
+	}
+}
+
+// variantMatchingStmts tries every variant, first rejecting unknown properties
+// and then ignoring them. A oneOf value must match exactly one variant, an anyOf
+// value takes the first matching one.
+func (g *Generator) variantMatchingStmts(modelName string, interfaceName string,
+	variants []sumVariant, exclusive bool,
+) []ast.Stmt {
+	g.AddDecodeVariant()
+
+	loopBody := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{I("matches")},
+						Type:  &ast.ArrayType{Elt: I(interfaceName)},
+					},
+				},
+			},
+		},
+	}
+	for _, variant := range variants {
+		args := []ast.Expr{I("data"), I("strict")}
+		for _, name := range variant.required {
+			args = append(args, Str(name))
+		}
+		loopBody = append(loopBody, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{I("value"), I("ok")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun:  &ast.IndexExpr{X: I("decodeVariant"), Index: I(variant.typeName)},
+						Args: args,
+					},
+				},
+			},
+			Cond: I("ok"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I("matches")},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun:  I("append"),
+								Args: []ast.Expr{I("matches"), I("value")},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if exclusive {
+		loopBody = append(loopBody, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I("matches")}},
+				Op: token.GTR,
+				Y:  intLit("1"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					Ret1(&ast.CallExpr{
+						Fun:  Sel(I("errors"), "New"),
+						Args: []ast.Expr{Str(modelName + ": value matches more than one variant")},
+					}),
+				},
+			},
+		})
+	}
+	loopBody = append(loopBody, &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  &ast.CallExpr{Fun: I("len"), Args: []ast.Expr{I("matches")}},
+			Op: token.GTR,
+			Y:  intLit("0"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{Sel(I("s"), "Value")},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{&ast.IndexExpr{X: I("matches"), Index: intLit("0")}},
+				},
+				Ret1(I("nil")),
+			},
+		},
+	})
+
+	return []ast.Stmt{
+		&ast.RangeStmt{
+			Key:   I("_"),
+			Value: I("strict"),
+			Tok:   token.DEFINE,
+			X: &ast.CompositeLit{
+				Type: &ast.ArrayType{Elt: I("bool")},
+				Elts: []ast.Expr{I("true"), I("false")},
+			},
+			Body: &ast.BlockStmt{List: loopBody},
+		},
+		Ret1(&ast.CallExpr{
+			Fun:  Sel(I("errors"), "New"),
+			Args: []ast.Expr{Str(modelName + ": value matches none of the variants")},
+		}),
+	}
+}
+
+func (g *Generator) AddDecodeVariant() {
+	if g.SchemasFile.hasDecodeVariant {
+		return
+	}
+	g.SchemasFile.hasDecodeVariant = true
+	g.AddSchemasImport("bytes")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", decodeVariantSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.SchemasFile.funcDecls = append(g.SchemasFile.funcDecls, file.Decls...)
+}
+
+// AddSumValidate emits the layer-1 validator of a sum type: the value is decoded
+// to find the chosen variant, whose own validator then checks the JSON.
+func (g *Generator) AddSumValidate(modelName string, variants []sumVariant) {
+	g.AddHandlersImport("encoding/json")
+	modelsPackage := g.GetCurrentModelsPackage()
+
+	body := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{I("value")},
+						Type:  Sel(I(modelsPackage), modelName),
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun:  Sel(I("json"), "Unmarshal"),
+					Args: []ast.Expr{I("jsonData"), Amp(I("value"))},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+		},
+	}
+
+	clauses := make([]ast.Stmt, 0, len(variants))
+	for _, variant := range variants {
+		if !variant.validate {
+			continue
+		}
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{Sel(I(modelsPackage), variant.typeName)},
+			Body: []ast.Stmt{
+				Ret1(&ast.CallExpr{
+					Fun:  g.GetValidateFuncStmt(variant.typeName, ""),
+					Args: []ast.Expr{I("jsonData")},
+				}),
+			},
+		})
+	}
+	if len(clauses) > 0 {
+		body = append(body, &ast.TypeSwitchStmt{
+			Assign: &ast.ExprStmt{X: &ast.TypeAssertExpr{X: Sel(I("value"), "Value")}},
+			Body:   &ast.BlockStmt{List: clauses},
+		})
+	}
+	body = append(body, Ret1(I("nil")))
+
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("Validate"+modelName+"JSON",
+		nil,
+		[]*ast.Field{
+			Field("jsonData", Sel(I("json"), "RawMessage"), ""),
+		},
+		[]*ast.Field{
+			Field("", I("error"), ""),
+		},
+		body,
+	))
+}
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// importNames are the names generated code imports the packages sharing a
+// name with another import under.
+var importNames = map[string]string{
+	"go.opentelemetry.io/otel/metric/noop": "metricnoop",
+	"go.opentelemetry.io/otel/trace/noop":  "tracenoop",
+}
+
+const telemetrySrc = `package _
+
+// WithTracerProvider traces every request with a span of tp, named by the
+// operationId.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(h *Handler) { h.tracerProvider = tp }
+}
+
+// WithMeterProvider records the request duration and the validation failures
+// of every operation with the instruments of mp.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(h *Handler) { h.meterProvider = mp }
+}
+
+type telemetry struct {
+	tracer             trace.Tracer
+	requestDuration    metric.Float64Histogram
+	validationFailures metric.Int64Counter
+}
+
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) telemetry {
+	meter := mp.Meter(instrumentationName)
+	// the instruments are usable even when their creation fails
+	requestDuration, _ := meter.Float64Histogram("http.server.request.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of the requests of an operation."))
+	validationFailures, _ := meter.Int64Counter("http.server.validation.failures",
+		metric.WithUnit("{request}"), metric.WithDescription("Requests of an operation failing validation."))
+	return telemetry{
+		tracer:             tp.Tracer(instrumentationName),
+		requestDuration:    requestDuration,
+		validationFailures: validationFailures,
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// instrument starts the span of a request of the operation id; the returned
+// func ends it and records the duration with the status code.
+func (h *Handler) instrument(w http.ResponseWriter, r *http.Request, id OperationID, method string, route string) (http.ResponseWriter, *http.Request, func()) {
+	start := time.Now()
+	attrs := []attribute.KeyValue{
+		attribute.String("operation.id", string(id)),
+		attribute.String("http.request.method", method),
+		attribute.String("http.route", route),
+	}
+	ctx, span := h.telemetry.tracer.Start(r.Context(), string(id),
+		trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	return recorder, r.WithContext(ctx), func() {
+		status := attribute.Int("http.response.status_code", recorder.status)
+		span.SetAttributes(status)
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+		span.End()
+		h.telemetry.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(append(attrs, status)...))
+	}
+}
+
+// recordValidationError adds err to the span of the request and counts the
+// failure.
+func (h *Handler) recordValidationError(r *http.Request, id OperationID, err error) {
+	span := trace.SpanFromContext(r.Context())
+	span.SetAttributes(attribute.Bool("validation.failed", true))
+	span.RecordError(err)
+	h.telemetry.validationFailures.Add(r.Context(), 1, metric.WithAttributes(attribute.String("operation.id", string(id))))
+}
+`
+
+// AddTelemetryDecls adds the options and helpers instrumenting the requests
+// with OpenTelemetry, with -otel.
+func (g *Generator) AddTelemetryDecls() {
+	if !g.Opts.OTel {
+		return
+	}
+	for _, path := range []string{
+		"time",
+		"go.opentelemetry.io/otel/attribute",
+		"go.opentelemetry.io/otel/codes",
+		"go.opentelemetry.io/otel/metric",
+		"go.opentelemetry.io/otel/metric/noop",
+		"go.opentelemetry.io/otel/trace",
+		"go.opentelemetry.io/otel/trace/noop",
+	} {
+		g.AddHandlersImport(path)
+	}
+
+	src := telemetrySrc + "\nconst instrumentationName = " + strconv.Quote(g.ImportPrefix) + "\n"
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// addTelemetryDependencies adds the providers, noop until set by an option,
+// and the instruments created from them to the Handler.
+func (g *Generator) addTelemetryDependencies() {
+	if !g.Opts.OTel {
+		return
+	}
+	g.HandlersFile.handlerDeclQAFieldList.List = append(g.HandlersFile.handlerDeclQAFieldList.List,
+		Field("tracerProvider", Sel(I("trace"), "TracerProvider"), ""),
+		Field("meterProvider", Sel(I("metric"), "MeterProvider"), ""),
+		Field("telemetry", I("telemetry"), ""),
+	)
+	g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts = append(
+		g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts,
+		&ast.KeyValueExpr{
+			Key:   I("tracerProvider"),
+			Value: &ast.CallExpr{Fun: Sel(I("tracenoop"), "NewTracerProvider")},
+		},
+		&ast.KeyValueExpr{
+			Key:   I("meterProvider"),
+			Value: &ast.CallExpr{Fun: Sel(I("metricnoop"), "NewMeterProvider")},
+		},
+	)
+}
+
+// telemetryInitStmts returns the statement creating the instruments once the
+// options have set the providers.
+func (g *Generator) telemetryInitStmts() []ast.Stmt {
+	if !g.Opts.OTel {
+		return nil
+	}
+
+	return []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{Sel(I("h"), "telemetry")},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{&ast.CallExpr{
+			Fun:  I("newTelemetry"),
+			Args: []ast.Expr{Sel(I("h"), "tracerProvider"), Sel(I("h"), "meterProvider")},
+		}},
+	}}
+}
+
+// instrumentStmts returns the statements starting the span of a request of the
+// operation and ending it when handle<Op>Request returns.
+func (g *Generator) instrumentStmts(baseName string, method string, pathName string) []ast.Stmt {
+	if !g.Opts.OTel {
+		return nil
+	}
+
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("w"), I("r"), I("end")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: Sel(I("h"), "instrument"),
+				Args: []ast.Expr{
+					I("w"), I("r"), I("Operation" + baseName),
+					Str(strings.ToUpper(method)), Str(pathName),
+				},
+			}},
+		},
+		&ast.DeferStmt{Call: &ast.CallExpr{Fun: I("end")}},
+	}
+}
+
+// recordValidationErrorStmts returns the statement recording the validation
+// failure err of a request of the operation.
+func (g *Generator) recordValidationErrorStmts(baseName string) []ast.Stmt {
+	if !g.Opts.OTel {
+		return nil
+	}
+
+	return []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+		Fun:  Sel(I("h"), "recordValidationError"),
+		Args: []ast.Expr{I("r"), I("Operation" + baseName), I("err")},
+	}}}
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"mime"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
 	"github.com/go-playground/validator/v10"
 	"packagename/imports/models"
 )
@@ -17,21 +20,43 @@ import (
 type PostExampleHandler interface {
 	HandlePostExample(ctx context.Context, r packagenamemodels.PostExampleRequest) (*packagenamemodels.PostExampleResponse, error)
 }
+
+const (
+	OperationPostExample OperationID = "PostExample"
+)
+
 type Handler struct {
-	validator    *validator.Validate
-	postExample  PostExampleHandler
-	errorHandler ErrorHandler
+	validator              *validator.Validate
+	postExample            PostExampleHandler
+	errorHandler           ErrorHandler
+	validationErrorHandler ValidationErrorHandler
+	validateResponses      bool
+	router                 http.Handler
+	middlewares            map[OperationID][]func(http.Handler) http.Handler
 }
 
 func NewHandler(postExample PostExampleHandler, opts ...Option) *Handler {
 	h := &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), postExample: postExample, errorHandler: DefaultErrorHandler}
+	h.validator.RegisterTagNameFunc(jsonTagName)
 	for _, opt := range opts {
 		opt(h)
 	}
+	h.router = h.Router()
 	return h
 }
 func (h *Handler) AddRoutes(router chi.Router) {
-	router.Post("/example", h.handlePostExample)
+	router.Method(http.MethodPost, "/example", h.operationHandler(OperationPostExample, h.handlePostExample))
+}
+func (h *Handler) PostExampleHTTPHandler() http.Handler {
+	return h.operationHandler(OperationPostExample, h.handlePostExample)
+}
+func (h *Handler) Router() http.Handler {
+	router := chi.NewRouter()
+	h.AddRoutes(router)
+	return router
+}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
 }
 func (h *Handler) parsePostExampleRequestBody(r *http.Request) (*packagenamemodels.Body, error) {
 	var bodyJSON json.RawMessage
@@ -55,9 +80,11 @@ func (h *Handler) parsePostExampleRequestBody(r *http.Request) (*packagenamemode
 	return &body, nil
 }
 func (h *Handler) parsePostExampleRequest(r *http.Request) (*packagenamemodels.PostExampleRequest, error) {
+	var errs ValidationError
 	body, err := h.parsePostExampleRequestBody(r)
-	if err != nil {
-		return nil, err
+	errs.merge("body", err)
+	if len(errs.Errors) > 0 {
+		return nil, &errs
 	}
 	return &packagenamemodels.PostExampleRequest{Body: *body}, nil
 }
@@ -82,11 +109,15 @@ func (h *Handler) writePostExampleResponse(w http.ResponseWriter, r *http.Reques
 func (h *Handler) handlePostExampleRequest(w http.ResponseWriter, r *http.Request) {
 	request, err := h.parsePostExampleRequest(r)
 	if err != nil {
-		h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+		h.handleValidationError(w, r, err)
 		return
 	}
 	ctx := r.Context()
 	response, err := h.postExample.HandlePostExample(ctx, *request)
+	if errors.Is(err, ErrNotImplemented) {
+		h.errorHandler(w, r, http.StatusNotImplemented, "Not Implemented")
+		return
+	}
 	if err != nil || response == nil {
 		h.errorHandler(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
@@ -127,3 +158,210 @@ func (h *Handler) SetErrorHandler(eh ErrorHandler) {
 var DefaultErrorHandler ErrorHandler = func(w http.ResponseWriter, r *http.Request, status int, msg string) {
 	http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(msg)), status)
 }
+var ErrNotImplemented = errors.New("not implemented")
+
+func jsonTagName(field reflect.StructField) string {
+	return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+}
+
+type ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError)
+
+func WithValidationErrorHandler(veh ValidationErrorHandler) Option {
+	return func(h *Handler) {
+		h.validationErrorHandler = veh
+	}
+}
+
+type ProblemDetails struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+var ProblemDetailsHandler ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(ProblemDetails{Type: "about:blank", Title: http.StatusText(http.StatusBadRequest), Status: http.StatusBadRequest, Detail: "request validation failed", Instance: r.URL.Path, Errors: err.Errors})
+}
+
+func (h *Handler) handleValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		h.errorHandler(w, r, http.StatusRequestEntityTooLarge, "Request Entity Too Large")
+		return
+	}
+	var validationError *ValidationError
+	if h.validationErrorHandler != nil && errors.As(err, &validationError) {
+		h.validationErrorHandler(w, r, validationError)
+		return
+	}
+	h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+}
+
+type ResponseValidationError struct {
+	Operation  string
+	StatusCode int
+	Err        *ValidationError
+}
+
+func (e *ResponseValidationError) Error() string {
+	return fmt.Sprintf("%s: invalid %d response: %s", e.Operation, e.StatusCode, e.Err.Error())
+}
+func (e *ResponseValidationError) Unwrap() error {
+	return e.Err
+}
+func WithResponseValidation() Option {
+	return func(h *Handler) {
+		h.validateResponses = true
+	}
+}
+
+type OperationID string
+type operationIDKey struct{}
+
+func OperationIDFromContext(ctx context.Context) (OperationID, bool) {
+	id, ok := ctx.Value(operationIDKey{}).(OperationID)
+	return id, ok
+}
+func WithOperationMiddleware(id OperationID, mw ...func(http.Handler) http.Handler) Option {
+	return func(h *Handler) {
+		if h.middlewares == nil {
+			h.middlewares = make(map[OperationID][]func(http.Handler) http.Handler)
+		}
+		h.middlewares[id] = append(h.middlewares[id], mw...)
+	}
+}
+func (h *Handler) operationHandler(id OperationID, handle http.HandlerFunc) http.Handler {
+	var handler http.Handler = handle
+	middlewares := h.middlewares[id]
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), operationIDKey{}, id)))
+	})
+}
+
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+type ValidationError struct {
+	Errors []FieldError
+	causes []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, field := range e.Errors {
+		if field.Pointer == "" {
+			messages = append(messages, field.Message)
+			continue
+		}
+		messages = append(messages, field.Pointer+": "+field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+func (e *ValidationError) Unwrap() []error {
+	return e.causes
+}
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(tokens ...string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+		pointer.WriteString("/" + jsonPointerEscaper.Replace(token))
+	}
+	return pointer.String()
+}
+func (e *ValidationError) add(pointer string, rule string, message string) {
+	for _, field := range e.Errors {
+		if field.Pointer == pointer {
+			return
+		}
+	}
+	e.Errors = append(e.Errors, FieldError{Pointer: pointer, Rule: rule, Message: message})
+}
+func (e *ValidationError) addParam(name string, err error) {
+	var field *FieldError
+	if errors.As(err, &field) {
+		e.add(jsonPointer(name), field.Rule, field.Message)
+		return
+	}
+	e.add(jsonPointer(name), "type", err.Error())
+}
+func (e *ValidationError) merge(token string, err error) {
+	if err == nil {
+		return
+	}
+	prefix := jsonPointer(token)
+	var nested *ValidationError
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &nested):
+		for _, field := range nested.Errors {
+			e.add(prefix+field.Pointer, field.Rule, field.Message)
+		}
+		e.causes = append(e.causes, nested.causes...)
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			rule, message := validatorRule(fieldError)
+			e.add(prefix+namespacePointer(fieldError.Namespace()), rule, message)
+		}
+	case errors.As(err, &typeError):
+		pointer := prefix
+		if typeError.Field != "" {
+			pointer += jsonPointer(strings.Split(typeError.Field, ".")...)
+		}
+		e.add(pointer, "type", "value of type "+typeError.Value+" is not "+typeError.Type.String())
+	default:
+		e.add(prefix, "invalid", err.Error())
+		e.causes = append(e.causes, err)
+	}
+}
+func namespacePointer(namespace string) string {
+	if !strings.HasPrefix(namespace, "[") {
+		index := strings.IndexAny(namespace, ".[")
+		if index < 0 {
+			return ""
+		}
+		namespace = strings.TrimPrefix(namespace[index:], ".")
+	}
+	tokens := strings.FieldsFunc(namespace, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	})
+	filtered := tokens[:0]
+	for _, token := range tokens {
+		if token != "-" {
+			filtered = append(filtered, token)
+		}
+	}
+	return jsonPointer(filtered...)
+}
+func validatorRule(fieldError validator.FieldError) (string, string) {
+	rule := fieldError.Tag()
+	if fieldError.Param() != "" {
+		rule += "=" + fieldError.Param()
+	}
+	return fieldError.Tag(), "value must satisfy " + rule
+}
@@ -3,9 +3,9 @@
 package packagenamemodels
 
 type OpQueryParams struct {
-	Limit  int     `json:"limit" validate:"required,min=1,max=100"`
-	Offset int64   `json:"offset" validate:"required"`
-	Ratio  float64 `json:"ratio" validate:"required"`
+	Limit  int     `json:"limit" validate:"min=1,max=100"`
+	Offset int64   `json:"offset"`
+	Ratio  float64 `json:"ratio"`
 	Page   *int32  `json:"page,omitempty" validate:"omitempty"`
 }
 type OpRequest struct {
@@ -5,11 +5,11 @@ package packagenamemodels
 import "time"
 
 type OpQueryParams struct {
-	From time.Time `json:"from" validate:"required"`
-	To   time.Time `json:"to" validate:"required"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
 }
 type OpHeaders struct {
-	XSince time.Time `json:"X-Since" validate:"required"`
+	XSince time.Time `json:"X-Since"`
 }
 type OpRequest struct {
 	Query   OpQueryParams
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"mime"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-faster/errors"
 	"github.com/go-playground/validator/v10"
@@ -21,23 +23,49 @@ type GetExample2Handler interface {
 type PostExampleParamNameHandler interface {
 	HandlePostExampleParamName(ctx context.Context, r packagenamemodels.PostExampleParamNameRequest) (*packagenamemodels.PostExampleParamNameResponse, error)
 }
+
+const (
+	OperationGetExample2          OperationID = "GetExample2"
+	OperationPostExampleParamName OperationID = "PostExampleParamName"
+)
+
 type Handler struct {
-	validator            *validator.Validate
-	getExample2          GetExample2Handler
-	postExampleParamName PostExampleParamNameHandler
-	errorHandler         ErrorHandler
+	validator              *validator.Validate
+	getExample2            GetExample2Handler
+	postExampleParamName   PostExampleParamNameHandler
+	errorHandler           ErrorHandler
+	validationErrorHandler ValidationErrorHandler
+	validateResponses      bool
+	router                 http.Handler
+	middlewares            map[OperationID][]func(http.Handler) http.Handler
 }
 
 func NewHandler(getExample2 GetExample2Handler, postExampleParamName PostExampleParamNameHandler, opts ...Option) *Handler {
 	h := &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), getExample2: getExample2, postExampleParamName: postExampleParamName, errorHandler: DefaultErrorHandler}
+	h.validator.RegisterTagNameFunc(jsonTagName)
 	for _, opt := range opts {
 		opt(h)
 	}
+	h.router = h.Router()
 	return h
 }
 func (h *Handler) AddRoutes(router chi.Router) {
-	router.Get("/example2", h.handleGetExample2)
-	router.Post("/example/{param_name}", h.handlePostExampleParamName)
+	router.Method(http.MethodGet, "/example2", h.operationHandler(OperationGetExample2, h.handleGetExample2))
+	router.Method(http.MethodPost, "/example/{param_name}", h.operationHandler(OperationPostExampleParamName, h.handlePostExampleParamName))
+}
+func (h *Handler) GetExample2HTTPHandler() http.Handler {
+	return h.operationHandler(OperationGetExample2, h.handleGetExample2)
+}
+func (h *Handler) PostExampleParamNameHTTPHandler() http.Handler {
+	return h.operationHandler(OperationPostExampleParamName, h.handlePostExampleParamName)
+}
+func (h *Handler) Router() http.Handler {
+	router := chi.NewRouter()
+	h.AddRoutes(router)
+	return router
+}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
 }
 func (h *Handler) parseGetExample2Request(r *http.Request) (*packagenamemodels.GetExample2Request, error) {
 	return &packagenamemodels.GetExample2Request{}, nil
@@ -63,11 +91,15 @@ func (h *Handler) writeGetExample2Response(w http.ResponseWriter, r *http.Reques
 func (h *Handler) handleGetExample2Request(w http.ResponseWriter, r *http.Request) {
 	request, err := h.parseGetExample2Request(r)
 	if err != nil {
-		h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+		h.handleValidationError(w, r, err)
 		return
 	}
 	ctx := r.Context()
 	response, err := h.getExample2.HandleGetExample2(ctx, *request)
+	if errors.Is(err, ErrNotImplemented) {
+		h.errorHandler(w, r, http.StatusNotImplemented, "Not Implemented")
+		return
+	}
 	if err != nil || response == nil {
 		h.errorHandler(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
@@ -80,42 +112,51 @@ func (h *Handler) handleGetExample2(w http.ResponseWriter, r *http.Request) {
 }
 func (h *Handler) parsePostExampleParamNamePathParams(r *http.Request) (*packagenamemodels.PostExampleParamNamePathParams, error) {
 	var pathParams packagenamemodels.PostExampleParamNamePathParams
-	paramName := chi.URLParam(r, "param_name")
-	if paramName == "" {
-		return nil, errors.New("param_name path param is required")
-	}
-	pathParams.ParamName = paramName
-	err := h.validator.Struct(pathParams)
-	if err != nil {
-		return nil, err
+	var errs ValidationError
+	if err := func() error {
+		paramName := chi.URLParam(r, "param_name")
+		if paramName == "" {
+			return &FieldError{Rule: "required", Message: "param_name path param is required"}
+		}
+		pathParams.ParamName = paramName
+		return nil
+	}(); err != nil {
+		errs.addParam("param_name", err)
 	}
-	return &pathParams, nil
+	errs.merge("", h.validator.Struct(pathParams))
+	return &pathParams, errs.Err()
 }
 func (h *Handler) parsePostExampleParamNameQueryParams(r *http.Request) (*packagenamemodels.PostExampleParamNameQueryParams, error) {
 	var queryParams packagenamemodels.PostExampleParamNameQueryParams
-	paramName2 := r.URL.Query().Get("param_name2")
-	if paramName2 == "" {
-		return nil, errors.New("param_name2 query param is required")
-	}
-	queryParams.ParamName2 = paramName2
-	err := h.validator.Struct(queryParams)
-	if err != nil {
-		return nil, err
+	var errs ValidationError
+	if err := func() error {
+		paramName2 := r.URL.Query().Get("param_name2")
+		if paramName2 == "" {
+			return &FieldError{Rule: "required", Message: "param_name2 query param is required"}
+		}
+		queryParams.ParamName2 = paramName2
+		return nil
+	}(); err != nil {
+		errs.addParam("param_name2", err)
 	}
-	return &queryParams, nil
+	errs.merge("", h.validator.Struct(queryParams))
+	return &queryParams, errs.Err()
 }
 func (h *Handler) parsePostExampleParamNameHeaders(r *http.Request) (*packagenamemodels.PostExampleParamNameHeaders, error) {
 	var headers packagenamemodels.PostExampleParamNameHeaders
-	xHeader := r.Header.Get("X-Header")
-	if xHeader == "" {
-		return nil, errors.New("X-Header header is required")
-	}
-	headers.XHeader = xHeader
-	err := h.validator.Struct(headers)
-	if err != nil {
-		return nil, err
+	var errs ValidationError
+	if err := func() error {
+		xHeader := r.Header.Get("X-Header")
+		if xHeader == "" {
+			return &FieldError{Rule: "required", Message: "X-Header header is required"}
+		}
+		headers.XHeader = xHeader
+		return nil
+	}(); err != nil {
+		errs.addParam("X-Header", err)
 	}
-	return &headers, nil
+	errs.merge("", h.validator.Struct(headers))
+	return &headers, errs.Err()
 }
 func containsNull(data json.RawMessage) bool {
 	var temp any
@@ -126,25 +167,27 @@ func containsNull(data json.RawMessage) bool {
 	return temp == nil
 }
 func ValidatePostExampleParamNameRequestBodyJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"code": true}
+	requiredFields := []string{"code"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func (h *Handler) parsePostExampleParamNameRequestBody(r *http.Request) (*packagenamemodels.PostExampleParamNameRequestBody, error) {
 	var bodyJSON json.RawMessage
@@ -168,21 +211,17 @@ func (h *Handler) parsePostExampleParamNameRequestBody(r *http.Request) (*packag
 	return &body, nil
 }
 func (h *Handler) parsePostExampleParamNameRequest(r *http.Request) (*packagenamemodels.PostExampleParamNameRequest, error) {
+	var errs ValidationError
 	pathParams, err := h.parsePostExampleParamNamePathParams(r)
-	if err != nil {
-		return nil, err
-	}
+	errs.merge("path", err)
 	queryParams, err := h.parsePostExampleParamNameQueryParams(r)
-	if err != nil {
-		return nil, err
-	}
+	errs.merge("query", err)
 	headers, err := h.parsePostExampleParamNameHeaders(r)
-	if err != nil {
-		return nil, err
-	}
+	errs.merge("header", err)
 	body, err := h.parsePostExampleParamNameRequestBody(r)
-	if err != nil {
-		return nil, err
+	errs.merge("body", err)
+	if len(errs.Errors) > 0 {
+		return nil, &errs
 	}
 	return &packagenamemodels.PostExampleParamNameRequest{Path: *pathParams, Query: *queryParams, Headers: *headers, Body: *body}, nil
 }
@@ -207,6 +246,14 @@ func (h *Handler) writePostExampleParamName200ResponseHeaders(w http.ResponseWri
 		w.Header().Set(key, value)
 	}
 }
+func (h *Handler) validatePostExampleParamName200Response(resp *packagenamemodels.PostExampleParamNameResponse200) error {
+	var errs ValidationError
+	errs.merge("header", h.validator.Struct(resp.Headers))
+	if len(errs.Errors) > 0 {
+		return &ResponseValidationError{Operation: "PostExampleParamName", StatusCode: 200, Err: &errs}
+	}
+	return nil
+}
 func (h *Handler) writePostExampleParamNameResponse(w http.ResponseWriter, r *http.Request, response *packagenamemodels.PostExampleParamNameResponse) {
 	switch response.StatusCode {
 	case 200:
@@ -214,6 +261,12 @@ func (h *Handler) writePostExampleParamNameResponse(w http.ResponseWriter, r *ht
 			h.errorHandler(w, r, http.StatusInternalServerError, "Internal Server Error")
 			return
 		}
+		if h.validateResponses {
+			if err := h.validatePostExampleParamName200Response(response.Response200); err != nil {
+				h.errorHandler(w, r, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
 		h.writePostExampleParamName200ResponseHeaders(w, r, response.Response200)
 		w.WriteHeader(response.StatusCode)
 		h.writePostExampleParamName200Response(w, r, response.Response200)
@@ -224,11 +277,15 @@ func (h *Handler) writePostExampleParamNameResponse(w http.ResponseWriter, r *ht
 func (h *Handler) handlePostExampleParamNameRequest(w http.ResponseWriter, r *http.Request) {
 	request, err := h.parsePostExampleParamNameRequest(r)
 	if err != nil {
-		h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+		h.handleValidationError(w, r, err)
 		return
 	}
 	ctx := r.Context()
 	response, err := h.postExampleParamName.HandlePostExampleParamName(ctx, *request)
+	if errors.Is(err, ErrNotImplemented) {
+		h.errorHandler(w, r, http.StatusNotImplemented, "Not Implemented")
+		return
+	}
 	if err != nil || response == nil {
 		h.errorHandler(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
@@ -266,3 +323,210 @@ func (h *Handler) SetErrorHandler(eh ErrorHandler) {
 var DefaultErrorHandler ErrorHandler = func(w http.ResponseWriter, r *http.Request, status int, msg string) {
 	http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(msg)), status)
 }
+var ErrNotImplemented = errors.New("not implemented")
+
+func jsonTagName(field reflect.StructField) string {
+	return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+}
+
+type ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError)
+
+func WithValidationErrorHandler(veh ValidationErrorHandler) Option {
+	return func(h *Handler) {
+		h.validationErrorHandler = veh
+	}
+}
+
+type ProblemDetails struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+var ProblemDetailsHandler ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(ProblemDetails{Type: "about:blank", Title: http.StatusText(http.StatusBadRequest), Status: http.StatusBadRequest, Detail: "request validation failed", Instance: r.URL.Path, Errors: err.Errors})
+}
+
+func (h *Handler) handleValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		h.errorHandler(w, r, http.StatusRequestEntityTooLarge, "Request Entity Too Large")
+		return
+	}
+	var validationError *ValidationError
+	if h.validationErrorHandler != nil && errors.As(err, &validationError) {
+		h.validationErrorHandler(w, r, validationError)
+		return
+	}
+	h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+}
+
+type ResponseValidationError struct {
+	Operation  string
+	StatusCode int
+	Err        *ValidationError
+}
+
+func (e *ResponseValidationError) Error() string {
+	return fmt.Sprintf("%s: invalid %d response: %s", e.Operation, e.StatusCode, e.Err.Error())
+}
+func (e *ResponseValidationError) Unwrap() error {
+	return e.Err
+}
+func WithResponseValidation() Option {
+	return func(h *Handler) {
+		h.validateResponses = true
+	}
+}
+
+type OperationID string
+type operationIDKey struct{}
+
+func OperationIDFromContext(ctx context.Context) (OperationID, bool) {
+	id, ok := ctx.Value(operationIDKey{}).(OperationID)
+	return id, ok
+}
+func WithOperationMiddleware(id OperationID, mw ...func(http.Handler) http.Handler) Option {
+	return func(h *Handler) {
+		if h.middlewares == nil {
+			h.middlewares = make(map[OperationID][]func(http.Handler) http.Handler)
+		}
+		h.middlewares[id] = append(h.middlewares[id], mw...)
+	}
+}
+func (h *Handler) operationHandler(id OperationID, handle http.HandlerFunc) http.Handler {
+	var handler http.Handler = handle
+	middlewares := h.middlewares[id]
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), operationIDKey{}, id)))
+	})
+}
+
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+type ValidationError struct {
+	Errors []FieldError
+	causes []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, field := range e.Errors {
+		if field.Pointer == "" {
+			messages = append(messages, field.Message)
+			continue
+		}
+		messages = append(messages, field.Pointer+": "+field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+func (e *ValidationError) Unwrap() []error {
+	return e.causes
+}
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(tokens ...string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+		pointer.WriteString("/" + jsonPointerEscaper.Replace(token))
+	}
+	return pointer.String()
+}
+func (e *ValidationError) add(pointer string, rule string, message string) {
+	for _, field := range e.Errors {
+		if field.Pointer == pointer {
+			return
+		}
+	}
+	e.Errors = append(e.Errors, FieldError{Pointer: pointer, Rule: rule, Message: message})
+}
+func (e *ValidationError) addParam(name string, err error) {
+	var field *FieldError
+	if errors.As(err, &field) {
+		e.add(jsonPointer(name), field.Rule, field.Message)
+		return
+	}
+	e.add(jsonPointer(name), "type", err.Error())
+}
+func (e *ValidationError) merge(token string, err error) {
+	if err == nil {
+		return
+	}
+	prefix := jsonPointer(token)
+	var nested *ValidationError
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &nested):
+		for _, field := range nested.Errors {
+			e.add(prefix+field.Pointer, field.Rule, field.Message)
+		}
+		e.causes = append(e.causes, nested.causes...)
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			rule, message := validatorRule(fieldError)
+			e.add(prefix+namespacePointer(fieldError.Namespace()), rule, message)
+		}
+	case errors.As(err, &typeError):
+		pointer := prefix
+		if typeError.Field != "" {
+			pointer += jsonPointer(strings.Split(typeError.Field, ".")...)
+		}
+		e.add(pointer, "type", "value of type "+typeError.Value+" is not "+typeError.Type.String())
+	default:
+		e.add(prefix, "invalid", err.Error())
+		e.causes = append(e.causes, err)
+	}
+}
+func namespacePointer(namespace string) string {
+	if !strings.HasPrefix(namespace, "[") {
+		index := strings.IndexAny(namespace, ".[")
+		if index < 0 {
+			return ""
+		}
+		namespace = strings.TrimPrefix(namespace[index:], ".")
+	}
+	tokens := strings.FieldsFunc(namespace, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	})
+	filtered := tokens[:0]
+	for _, token := range tokens {
+		if token != "-" {
+			filtered = append(filtered, token)
+		}
+	}
+	return jsonPointer(filtered...)
+}
+func validatorRule(fieldError validator.FieldError) (string, string) {
+	rule := fieldError.Tag()
+	if fieldError.Param() != "" {
+		rule += "=" + fieldError.Param()
+	}
+	return fieldError.Tag(), "value must satisfy " + rule
+}
@@ -11,13 +11,13 @@ type GetExample2Response struct {
 	Response200 *GetExample2Response200
 }
 type PostExampleParamNamePathParams struct {
-	ParamName string `json:"param_name" validate:"required"`
+	ParamName string `json:"param_name"`
 }
 type PostExampleParamNameQueryParams struct {
-	ParamName2 string `json:"param_name2" validate:"required"`
+	ParamName2 string `json:"param_name2"`
 }
 type PostExampleParamNameHeaders struct {
-	XHeader string `json:"X-Header" validate:"required"`
+	XHeader string `json:"X-Header"`
 }
 type PostExampleParamNameRequestBody struct {
 	Code string `json:"code"`
@@ -11,7 +11,25 @@ import (
 
 func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 	var validateTags []string
+	if schema == nil {
+		// untyped additionalProperties
+		return nil
+	}
 	switch {
+	case schema.Value.Type.Permits(openapi3.TypeObject) && isMapSchema(schema.Value):
+		if schema.Value.MinProps > 0 {
+			validateTags = append(validateTags, "min="+strconv.FormatUint(schema.Value.MinProps, 10))
+		}
+		if schema.Value.MaxProps != nil {
+			validateTags = append(validateTags, "max="+strconv.FormatUint(*schema.Value.MaxProps, 10))
+		}
+		validateTags = append(validateTags, "dive")
+		valueValidators := GetSchemaValidators(schema.Value.AdditionalProperties.Schema)
+		validateTags = append(validateTags, valueValidators...)
+
+	case isBinarySchema(schema.Value):
+		// files are checked by the content type of their encoding
+
 	case schema.Value.Type.Permits(openapi3.TypeString):
 		if schema.Value.MinLength > 0 {
 			validateTags = append(validateTags, "min="+strconv.FormatUint(schema.Value.MinLength, 10))
@@ -19,8 +37,8 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 		if schema.Value.MaxLength != nil {
 			validateTags = append(validateTags, "max="+strconv.FormatUint(*schema.Value.MaxLength, 10))
 		}
-		if schema.Value.Pattern != "" {
-			slog.Warn("pattern validator is not supported", slog.String("pattern", schema.Value.Pattern))
+		if schema.Value.Pattern != "" && !typedStringFormats[schema.Value.Format] {
+			validateTags = append(validateTags, PatternTag(schema.Value.Pattern))
 		}
 		if len(schema.Value.Enum) > 0 {
 			enumStrValues := make([]string, 0, len(schema.Value.Enum))
@@ -40,6 +58,14 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 			joinedEnum := strings.Join(enumStrValues, " ")
 			validateTags = append(validateTags, "oneof="+joinedEnum)
 		}
+		if schema.Value.Format == "decimal" {
+			if schema.Value.MultipleOf != nil {
+				validateTags = append(validateTags, "multipleof="+fmt.Sprint(*schema.Value.MultipleOf))
+			}
+			if schema.Value.Min != nil || schema.Value.Max != nil {
+				slog.Warn("minimum/maximum validators are not supported for decimal strings")
+			}
+		}
 		switch schema.Value.Format {
 		case "ip":
 			validateTags = append(validateTags, "ip")
@@ -52,21 +78,7 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 		}
 
 	case schema.Value.Type.Permits(openapi3.TypeInteger):
-		if schema.Value.Min != nil {
-			validateTags = append(validateTags, "min="+fmt.Sprint(*schema.Value.Min))
-		}
-		if schema.Value.Max != nil {
-			validateTags = append(validateTags, "max="+fmt.Sprint(*schema.Value.Max))
-		}
-		if schema.Value.MultipleOf != nil {
-			slog.Warn("multipleOf validator is not supported")
-		}
-		if schema.Value.ExclusiveMax {
-			slog.Warn("exclusiveMax validator is not supported")
-		}
-		if schema.Value.ExclusiveMin {
-			slog.Warn("exclusiveMin validator is not supported")
-		}
+		validateTags = append(validateTags, getNumericBoundValidators(schema.Value)...)
 		if len(schema.Value.Enum) > 0 {
 			enumStrValues := make([]string, 0, len(schema.Value.Enum))
 			for _, enumValue := range schema.Value.Enum {
@@ -78,21 +90,7 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 		}
 
 	case schema.Value.Type.Permits(openapi3.TypeNumber):
-		if schema.Value.Min != nil {
-			validateTags = append(validateTags, "min="+fmt.Sprint(*schema.Value.Min))
-		}
-		if schema.Value.Max != nil {
-			validateTags = append(validateTags, "max="+fmt.Sprint(*schema.Value.Max))
-		}
-		if schema.Value.MultipleOf != nil {
-			slog.Warn("multipleOf validator is not supported")
-		}
-		if schema.Value.ExclusiveMax {
-			slog.Warn("exclusiveMax validator is not supported")
-		}
-		if schema.Value.ExclusiveMin {
-			slog.Warn("exclusiveMin validator is not supported")
-		}
+		validateTags = append(validateTags, getNumericBoundValidators(schema.Value)...)
 		if len(schema.Value.Enum) > 0 {
 			enumStrValues := make([]string, 0, len(schema.Value.Enum))
 			for _, enumValue := range schema.Value.Enum {
@@ -120,3 +118,28 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 
 	return validateTags
 }
+
+// getNumericBoundValidators maps minimum/maximum (exclusive or not) and multipleOf
+// of integer and number schemas.
+func getNumericBoundValidators(schema *openapi3.Schema) []string {
+	var validateTags []string
+	if schema.Min != nil {
+		tag := "min="
+		if schema.ExclusiveMin {
+			tag = "gt="
+		}
+		validateTags = append(validateTags, tag+fmt.Sprint(*schema.Min))
+	}
+	if schema.Max != nil {
+		tag := "max="
+		if schema.ExclusiveMax {
+			tag = "lt="
+		}
+		validateTags = append(validateTags, tag+fmt.Sprint(*schema.Max))
+	}
+	if schema.MultipleOf != nil {
+		validateTags = append(validateTags, "multipleof="+fmt.Sprint(*schema.MultipleOf))
+	}
+
+	return validateTags
+}
@@ -0,0 +1,368 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+const validationErrorSrc = `package _
+
+// FieldError describes a single failed check of a request.
+type FieldError struct {
+	Pointer string ` + "`json:\"pointer\"`" + `
+	Rule    string ` + "`json:\"rule\"`" + `
+	Message string ` + "`json:\"message\"`" + `
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationError collects every check a request failed.
+type ValidationError struct {
+	Errors []FieldError
+	// causes are the errors reported as invalid, like the
+	// *http.MaxBytesError of a body past its maximum size
+	causes []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, field := range e.Errors {
+		if field.Pointer == "" {
+			messages = append(messages, field.Message)
+			continue
+		}
+		messages = append(messages, field.Pointer+": "+field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the errors reported as invalid.
+func (e *ValidationError) Unwrap() []error {
+	return e.causes
+}
+
+// Err returns e, or nil when no check failed.
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(tokens ...string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+		pointer.WriteString("/" + jsonPointerEscaper.Replace(token))
+	}
+	return pointer.String()
+}
+
+// add records a failure unless the field already failed another check.
+func (e *ValidationError) add(pointer string, rule string, message string) {
+	for _, field := range e.Errors {
+		if field.Pointer == pointer {
+			return
+		}
+	}
+	e.Errors = append(e.Errors, FieldError{Pointer: pointer, Rule: rule, Message: message})
+}
+
+// addParam records the failure to read the parameter name.
+func (e *ValidationError) addParam(name string, err error) {
+	var field *FieldError
+	if errors.As(err, &field) {
+		e.add(jsonPointer(name), field.Rule, field.Message)
+		return
+	}
+	e.add(jsonPointer(name), "type", err.Error())
+}
+
+// merge records the failures of err under the JSON pointer token.
+func (e *ValidationError) merge(token string, err error) {
+	if err == nil {
+		return
+	}
+	prefix := jsonPointer(token)
+	var nested *ValidationError
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &nested):
+		for _, field := range nested.Errors {
+			e.add(prefix+field.Pointer, field.Rule, field.Message)
+		}
+		e.causes = append(e.causes, nested.causes...)
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			rule, message := validatorRule(fieldError)
+			e.add(prefix+namespacePointer(fieldError.Namespace()), rule, message)
+		}
+	case errors.As(err, &typeError):
+		pointer := prefix
+		if typeError.Field != "" {
+			pointer += jsonPointer(strings.Split(typeError.Field, ".")...)
+		}
+		e.add(pointer, "type", "value of type "+typeError.Value+" is not "+typeError.Type.String())
+	default:
+		e.add(prefix, "invalid", err.Error())
+		e.causes = append(e.causes, err)
+	}
+}
+
+// namespacePointer converts a validator namespace, like Type.items[0].name,
+// into a JSON pointer. Fields named "-" are not visible in JSON and skipped.
+func namespacePointer(namespace string) string {
+	if !strings.HasPrefix(namespace, "[") {
+		index := strings.IndexAny(namespace, ".[")
+		if index < 0 {
+			return ""
+		}
+		namespace = strings.TrimPrefix(namespace[index:], ".")
+	}
+	tokens := strings.FieldsFunc(namespace, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	})
+	filtered := tokens[:0]
+	for _, token := range tokens {
+		if token != "-" {
+			filtered = append(filtered, token)
+		}
+	}
+	return jsonPointer(filtered...)
+}
+
+`
+
+const validatorRuleSrc = `package _
+
+// validatorRule returns the rule a validator check failed and its message.
+func validatorRule(fieldError validator.FieldError) (string, string) {
+	rule := fieldError.Tag()
+	if fieldError.Param() != "" {
+		rule += "=" + fieldError.Param()
+	}
+	return fieldError.Tag(), "value must satisfy " + rule
+}
+`
+
+// patternValidatorRuleSrc reports the failures of pattern validators by their
+// pattern rather than their tag.
+const patternValidatorRuleSrc = `package _
+
+// validatorRule returns the rule a validator check failed and its message.
+func validatorRule(fieldError validator.FieldError) (string, string) {
+	if re, ok := patternValidators[fieldError.Tag()]; ok {
+		return "pattern", "value must match pattern " + re.String()
+	}
+	rule := fieldError.Tag()
+	if fieldError.Param() != "" {
+		rule += "=" + fieldError.Param()
+	}
+	return fieldError.Tag(), "value must satisfy " + rule
+}
+`
+
+const validationErrorHandlerSrc = `package _
+
+func jsonTagName(field reflect.StructField) string {
+	return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+}
+
+// ValidationErrorHandler writes the response to a request that failed validation.
+type ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError)
+
+func WithValidationErrorHandler(veh ValidationErrorHandler) Option {
+	return func(h *Handler) { h.validationErrorHandler = veh }
+}
+
+// ProblemDetails is an RFC 7807 problem, extended with the failed checks.
+type ProblemDetails struct {
+	Type     string       ` + "`json:\"type\"`" + `
+	Title    string       ` + "`json:\"title\"`" + `
+	Status   int          ` + "`json:\"status\"`" + `
+	Detail   string       ` + "`json:\"detail,omitempty\"`" + `
+	Instance string       ` + "`json:\"instance,omitempty\"`" + `
+	Errors   []FieldError ` + "`json:\"errors,omitempty\"`" + `
+}
+
+// ProblemDetailsHandler writes validation errors as application/problem+json.
+var ProblemDetailsHandler ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusBadRequest),
+		Status:   http.StatusBadRequest,
+		Detail:   "request validation failed",
+		Instance: r.URL.Path,
+		Errors:   err.Errors,
+	})
+}
+
+func (h *Handler) handleValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		h.errorHandler(w, r, http.StatusRequestEntityTooLarge, "Request Entity Too Large")
+		return
+	}
+	var validationError *ValidationError
+	if h.validationErrorHandler != nil && errors.As(err, &validationError) {
+		h.validationErrorHandler(w, r, validationError)
+		return
+	}
+	h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+}
+`
+
+func (g *Generator) AddValidationErrorDecls() {
+	if !g.HandlersFile.hasValidationErrors {
+		return
+	}
+	g.AddHandlersImport("encoding/json")
+	g.AddHandlersImport("strings")
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	ruleSrc := validatorRuleSrc
+	if len(g.HandlersFile.patterns) > 0 {
+		ruleSrc = patternValidatorRuleSrc
+	}
+	for _, src := range []string{validationErrorSrc, ruleSrc} {
+		file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+		if err != nil {
+			panic(err)
+		}
+		g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+	}
+}
+
+func (g *Generator) AddValidationErrorHandlerDecls() {
+	g.HandlersFile.hasValidationErrors = true
+	g.AddHandlersImport("encoding/json")
+	g.AddHandlersImport("net/http")
+	g.AddHandlersImport("reflect")
+	g.AddHandlersImport("strings")
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", validationErrorHandlerSrc, 0)
+	if err != nil {
+		panic(err)
+	}
+	g.HandlersFile.extraDecls = append(g.HandlersFile.extraDecls, file.Decls...)
+}
+
+// requiredParamError returns a field error failing the required rule.
+func requiredParamError(message string) ast.Expr {
+	return fieldError("required", message)
+}
+
+// fieldError returns a field error failing rule.
+func fieldError(rule string, message string) ast.Expr {
+	return Amp(&ast.CompositeLit{
+		Type: I("FieldError"),
+		Elts: []ast.Expr{
+			&ast.KeyValueExpr{Key: I("Rule"), Value: Str(rule)},
+			&ast.KeyValueExpr{Key: I("Message"), Value: Str(message)},
+		},
+	})
+}
+
+// collectParamErrors runs the statements reading the parameter name in a
+// closure, so that its failure is added to errs instead of ending the parse.
+func collectParamErrors(name string, stmts []ast.Stmt) []ast.Stmt {
+	returns := false
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			ret, ok := node.(*ast.ReturnStmt)
+			if ok && len(ret.Results) == 2 {
+				ret.Results = ret.Results[1:]
+				returns = true
+			}
+			return true
+		})
+	}
+	if !returns {
+		return stmts
+	}
+
+	return []ast.Stmt{&ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: &ast.FuncLit{
+					Type: &ast.FuncType{
+						Params:  &ast.FieldList{},
+						Results: &ast.FieldList{List: FieldA(Field("", I("error"), ""))},
+					},
+					Body: &ast.BlockStmt{List: append(stmts, Ret1(I("nil")))},
+				},
+			}},
+		},
+		Cond: Ne(I("err"), I("nil")),
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  Sel(I("errs"), "addParam"),
+			Args: []ast.Expr{Str(name), I("err")},
+		}}}},
+	}}
+}
+
+// collectedParamsEnd validates the parameters struct and returns it with the
+// collected failures.
+func collectedParamsEnd(paramsName string) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun: Sel(I("errs"), "merge"),
+			Args: []ast.Expr{Str(""), &ast.CallExpr{
+				Fun:  Sel(Sel(I("h"), "validator"), "Struct"),
+				Args: []ast.Expr{I(paramsName)},
+			}},
+		}},
+		Ret2(Amp(I(paramsName)), &ast.CallExpr{Fun: Sel(I("errs"), "Err")}),
+	}
+}
+
+// errsDecl declares the ValidationError a parse method collects failures in.
+func (g *Generator) errsDecl() ast.Stmt {
+	g.HandlersFile.hasValidationErrors = true
+	return &ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{I("errs")},
+					Type:  I("ValidationError"),
+				},
+			},
+		},
+	}
+}
+
+// addFieldError adds the failure of the property named by the field expression
+// to errs.
+func addFieldError(field ast.Expr, rule string, message ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{X: &ast.CallExpr{
+		Fun: Sel(I("errs"), "add"),
+		Args: []ast.Expr{
+			&ast.CallExpr{Fun: I("jsonPointer"), Args: []ast.Expr{field}},
+			Str(rule),
+			message,
+		},
+	}}
+}
+
+// mergeFieldErrors adds the failures returned by a nested validator to errs
+// under the token expression.
+func mergeFieldErrors(token ast.Expr, err ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{X: &ast.CallExpr{
+		Fun:  Sel(I("errs"), "merge"),
+		Args: []ast.Expr{token, err},
+	}}
+}
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"mime"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-faster/errors"
@@ -20,95 +22,144 @@ import (
 type CreateHandler interface {
 	HandleCreate(ctx context.Context, r apimodels.CreateRequest) (*apimodels.CreateResponse, error)
 }
+
+const (
+	OperationCreate OperationID = "create"
+)
+
 type Handler struct {
-	validator    *validator.Validate
-	create       CreateHandler
-	errorHandler ErrorHandler
+	validator              *validator.Validate
+	create                 CreateHandler
+	errorHandler           ErrorHandler
+	validationErrorHandler ValidationErrorHandler
+	validateResponses      bool
+	router                 http.Handler
+	middlewares            map[OperationID][]func(http.Handler) http.Handler
 }
 
 func NewHandler(create CreateHandler, opts ...Option) *Handler {
 	h := &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), create: create, errorHandler: DefaultErrorHandler}
+	h.validator.RegisterTagNameFunc(jsonTagName)
 	for _, opt := range opts {
 		opt(h)
 	}
+	h.router = h.Router()
 	return h
 }
 func (h *Handler) AddRoutes(router chi.Router) {
-	router.Post("/path/to/{param}/resours{suffix}", h.handleCreate)
+	router.Method(http.MethodPost, "/path/to/{param}/resours{suffix}", h.operationHandler(OperationCreate, h.handleCreate))
+}
+func (h *Handler) CreateHTTPHandler() http.Handler {
+	return h.operationHandler(OperationCreate, h.handleCreate)
+}
+func (h *Handler) Router() http.Handler {
+	router := chi.NewRouter()
+	h.AddRoutes(router)
+	return router
+}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
 }
 func (h *Handler) parseCreatePathParams(r *http.Request) (*apimodels.CreatePathParams, error) {
 	var pathParams apimodels.CreatePathParams
-	suffix := chi.URLParam(r, "suffix")
-	if suffix == "" {
-		return nil, errors.New("suffix path param is required")
-	}
-	pathParams.Suffix = suffix
-	param := chi.URLParam(r, "param")
-	if param == "" {
-		return nil, errors.New("param path param is required")
-	}
-	pathParams.Param = param
-	err := h.validator.Struct(pathParams)
-	if err != nil {
-		return nil, err
+	var errs ValidationError
+	if err := func() error {
+		suffix := chi.URLParam(r, "suffix")
+		if suffix == "" {
+			return &FieldError{Rule: "required", Message: "suffix path param is required"}
+		}
+		pathParams.Suffix = suffix
+		return nil
+	}(); err != nil {
+		errs.addParam("suffix", err)
+	}
+	if err := func() error {
+		param := chi.URLParam(r, "param")
+		if param == "" {
+			return &FieldError{Rule: "required", Message: "param path param is required"}
+		}
+		pathParams.Param = param
+		return nil
+	}(); err != nil {
+		errs.addParam("param", err)
 	}
-	return &pathParams, nil
+	errs.merge("", h.validator.Struct(pathParams))
+	return &pathParams, errs.Err()
 }
 func (h *Handler) parseCreateQueryParams(r *http.Request) (*apimodels.CreateQueryParams, error) {
 	var queryParams apimodels.CreateQueryParams
-	count := r.URL.Query().Get("count")
-	if count == "" {
-		return nil, errors.New("count query param is required")
-	}
-	queryParams.Count = count
-	err := h.validator.Struct(queryParams)
-	if err != nil {
-		return nil, err
+	var errs ValidationError
+	if err := func() error {
+		count := r.URL.Query().Get("count")
+		if count == "" {
+			return &FieldError{Rule: "required", Message: "count query param is required"}
+		}
+		queryParams.Count = count
+		return nil
+	}(); err != nil {
+		errs.addParam("count", err)
 	}
-	return &queryParams, nil
+	errs.merge("", h.validator.Struct(queryParams))
+	return &queryParams, errs.Err()
 }
 func (h *Handler) parseCreateHeaders(r *http.Request) (*apimodels.CreateHeaders, error) {
 	var headers apimodels.CreateHeaders
-	idempotencyKey := r.Header.Get("Idempotency-Key")
-	if idempotencyKey == "" {
-		return nil, errors.New("Idempotency-Key header is required")
-	}
-	headers.IdempotencyKey = idempotencyKey
-	optionalHeader := r.Header.Get("Optional-Header")
-	if optionalHeader != "" {
-		parsedOptionalHeader, err := time.Parse(time.RFC3339, optionalHeader)
-		if err != nil {
-			return nil, errors.Wrap(err, "OptionalHeader is not a valid date-time format")
+	var errs ValidationError
+	if err := func() error {
+		idempotencyKey := r.Header.Get("Idempotency-Key")
+		if idempotencyKey == "" {
+			return &FieldError{Rule: "required", Message: "Idempotency-Key header is required"}
 		}
-		headers.OptionalHeader = &parsedOptionalHeader
-	}
-	err := h.validator.Struct(headers)
-	if err != nil {
-		return nil, err
+		headers.IdempotencyKey = idempotencyKey
+		return nil
+	}(); err != nil {
+		errs.addParam("Idempotency-Key", err)
+	}
+	if err := func() error {
+		optionalHeader := r.Header.Get("Optional-Header")
+		if optionalHeader != "" {
+			parsedOptionalHeader, err := time.Parse(time.RFC3339, optionalHeader)
+			if err != nil {
+				return errors.Wrap(err, "OptionalHeader is not a valid date-time format")
+			}
+			headers.OptionalHeader = &parsedOptionalHeader
+		}
+		return nil
+	}(); err != nil {
+		errs.addParam("Optional-Header", err)
 	}
-	return &headers, nil
+	errs.merge("", h.validator.Struct(headers))
+	return &headers, errs.Err()
 }
 func (h *Handler) parseCreateCookies(r *http.Request) (*apimodels.CreateCookies, error) {
 	var cookies apimodels.CreateCookies
-	cookieParam, err := r.Cookie("cookie-param")
-	if err != nil && !errors.Is(err, http.ErrNoCookie) {
-		return nil, err
-	}
-	if err == nil {
-		cookieParamValue := cookieParam.Value
-		cookies.CookieParam = &cookieParamValue
-	}
-	requiredCookieParam, err := r.Cookie("required-cookie-param")
-	if err != nil {
-		return nil, err
+	var errs ValidationError
+	if err := func() error {
+		cookieParam, err := r.Cookie("cookie-param")
+		if err != nil && !errors.Is(err, http.ErrNoCookie) {
+			return err
+		}
+		if err == nil {
+			cookieParamValue := cookieParam.Value
+			cookies.CookieParam = &cookieParamValue
+		}
+		return nil
+	}(); err != nil {
+		errs.addParam("cookie-param", err)
 	}
-	requiredCookieParamValue := requiredCookieParam.Value
-	cookies.RequiredCookieParam = requiredCookieParamValue
-	err = h.validator.Struct(cookies)
-	if err != nil {
-		return nil, err
+	if err := func() error {
+		requiredCookieParam, err := r.Cookie("required-cookie-param")
+		if err != nil {
+			return &FieldError{Rule: "required", Message: "required-cookie-param cookie is required"}
+		}
+		requiredCookieParamValue := requiredCookieParam.Value
+		cookies.RequiredCookieParam = requiredCookieParamValue
+		return nil
+	}(); err != nil {
+		errs.addParam("required-cookie-param", err)
 	}
-	return &cookies, nil
+	errs.merge("", h.validator.Struct(cookies))
+	return &cookies, errs.Err()
 }
 func ValidateCreateRequestBodyObjectArrayItemJSON(_ json.RawMessage) error {
 	return nil
@@ -119,15 +170,13 @@ func ValidateCreateRequestBodyObjectArrayJSON(jsonData json.RawMessage) error {
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	for index, obj := range arr {
 		if !containsNull(obj) {
-			err = ValidateCreateRequestBodyObjectArrayItemJSON(obj)
-			if err != nil {
-				return errors.Wrapf(err, "error validating object at index %d", index)
-			}
+			errs.merge(strconv.Itoa(index), ValidateCreateRequestBodyObjectArrayItemJSON(obj))
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateCreateRequestBodyObjectFieldField2JSON(_ json.RawMessage) error {
 	return nil
@@ -146,65 +195,53 @@ func ValidateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage) error {
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
 	val, exists = obj["field2"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectFieldField2JSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field field2 is not valid")
-		}
+		errs.merge("field2", ValidateCreateRequestBodyObjectFieldField2JSON(val))
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateCreateRequestBodyJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"name": true}
+	requiredFields := []string{"name"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
 	val, exists = obj["external-ref2"]
 	if exists && !containsNull(val) {
-		err = def.ValidateExternalObjectJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field external-ref2 is not valid")
-		}
+		errs.merge("external-ref2", def.ValidateExternalObjectJSON(val))
 	}
 	val, exists = obj["field_to_validate_dive"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field field_to_validate_dive is not valid")
-		}
+		errs.merge("field_to_validate_dive", ValidateComplexObjectForDiveJSON(val))
 	}
 	val, exists = obj["object-array"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectArrayJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field object-array is not valid")
-		}
+		errs.merge("object-array", ValidateCreateRequestBodyObjectArrayJSON(val))
 	}
 	val, exists = obj["object-field"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectFieldJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field object-field is not valid")
-		}
+		errs.merge("object-field", ValidateCreateRequestBodyObjectFieldJSON(val))
 	}
-	return nil
+	return errs.Err()
 }
 func (h *Handler) parseCreateRequestBody(r *http.Request) (*apimodels.CreateRequestBody, error) {
 	var bodyJSON json.RawMessage
@@ -228,25 +265,19 @@ func (h *Handler) parseCreateRequestBody(r *http.Request) (*apimodels.CreateRequ
 	return &body, nil
 }
 func (h *Handler) parseCreateRequest(r *http.Request) (*apimodels.CreateRequest, error) {
+	var errs ValidationError
 	pathParams, err := h.parseCreatePathParams(r)
-	if err != nil {
-		return nil, err
-	}
+	errs.merge("path", err)
 	queryParams, err := h.parseCreateQueryParams(r)
-	if err != nil {
-		return nil, err
-	}
+	errs.merge("query", err)
 	headers, err := h.parseCreateHeaders(r)
-	if err != nil {
-		return nil, err
-	}
+	errs.merge("header", err)
 	cookieParams, err := h.parseCreateCookies(r)
-	if err != nil {
-		return nil, err
-	}
+	errs.merge("cookie", err)
 	body, err := h.parseCreateRequestBody(r)
-	if err != nil {
-		return nil, err
+	errs.merge("body", err)
+	if len(errs.Errors) > 0 {
+		return nil, &errs
 	}
 	return &apimodels.CreateRequest{Path: *pathParams, Query: *queryParams, Headers: *headers, Cookies: *cookieParams, Body: *body}, nil
 }
@@ -287,6 +318,22 @@ func Create404() *apimodels.CreateResponse {
 }
 func (h *Handler) writeCreate404Response(w http.ResponseWriter, r *http.Request, resp *apimodels.CreateResponse404) {
 }
+func (h *Handler) validateCreate200Response(resp *apimodels.CreateResponse200) error {
+	var errs ValidationError
+	bodyJSON, err := json.Marshal(resp.Body)
+	if err == nil {
+		err = ValidateNewResourseResponseJSON(bodyJSON)
+	}
+	if err == nil {
+		err = h.validator.Struct(resp.Body)
+	}
+	errs.merge("body", err)
+	errs.merge("header", h.validator.Struct(resp.Headers))
+	if len(errs.Errors) > 0 {
+		return &ResponseValidationError{Operation: "Create", StatusCode: 200, Err: &errs}
+	}
+	return nil
+}
 func (h *Handler) writeCreateResponse(w http.ResponseWriter, r *http.Request, response *apimodels.CreateResponse) {
 	switch response.StatusCode {
 	case 200:
@@ -294,6 +341,12 @@ func (h *Handler) writeCreateResponse(w http.ResponseWriter, r *http.Request, re
 			h.errorHandler(w, r, http.StatusInternalServerError, "Internal Server Error")
 			return
 		}
+		if h.validateResponses {
+			if err := h.validateCreate200Response(response.Response200); err != nil {
+				h.errorHandler(w, r, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
 		h.writeCreate200ResponseHeaders(w, r, response.Response200)
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(response.StatusCode)
@@ -321,11 +374,15 @@ func (h *Handler) writeCreateResponse(w http.ResponseWriter, r *http.Request, re
 func (h *Handler) handleCreateRequest(w http.ResponseWriter, r *http.Request) {
 	request, err := h.parseCreateRequest(r)
 	if err != nil {
-		h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+		h.handleValidationError(w, r, err)
 		return
 	}
 	ctx := r.Context()
 	response, err := h.create.HandleCreate(ctx, *request)
+	if errors.Is(err, ErrNotImplemented) {
+		h.errorHandler(w, r, http.StatusNotImplemented, "Not Implemented")
+		return
+	}
 	if err != nil || response == nil {
 		h.errorHandler(w, r, http.StatusInternalServerError, "Internal Server Error")
 		return
@@ -348,25 +405,27 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 func ValidateComplexObjectForDiveArrayObjectsOptionalItemJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"field1": true}
+	requiredFields := []string{"field1"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateComplexObjectForDiveArrayObjectsOptionalJSON(jsonData json.RawMessage) error {
 	var arr []json.RawMessage
@@ -374,36 +433,36 @@ func ValidateComplexObjectForDiveArrayObjectsOptionalJSON(jsonData json.RawMessa
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	for index, obj := range arr {
 		if !containsNull(obj) {
-			err = ValidateComplexObjectForDiveArrayObjectsOptionalItemJSON(obj)
-			if err != nil {
-				return errors.Wrapf(err, "error validating object at index %d", index)
-			}
+			errs.merge(strconv.Itoa(index), ValidateComplexObjectForDiveArrayObjectsOptionalItemJSON(obj))
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateComplexObjectForDiveArrayObjectsRequiredItemJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"field1": true}
+	requiredFields := []string{"field1"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateComplexObjectForDiveArrayObjectsRequiredJSON(jsonData json.RawMessage) error {
 	var arr []json.RawMessage
@@ -411,127 +470,121 @@ func ValidateComplexObjectForDiveArrayObjectsRequiredJSON(jsonData json.RawMessa
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	for index, obj := range arr {
 		if !containsNull(obj) {
-			err = ValidateComplexObjectForDiveArrayObjectsRequiredItemJSON(obj)
-			if err != nil {
-				return errors.Wrapf(err, "error validating object at index %d", index)
-			}
+			errs.merge(strconv.Itoa(index), ValidateComplexObjectForDiveArrayObjectsRequiredItemJSON(obj))
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateComplexObjectForDiveObjectFieldOptionalJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"field1": true}
+	requiredFields := []string{"field1"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateComplexObjectForDiveObjectFieldRequiredJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"field1": true}
+	requiredFields := []string{"field1"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateComplexObjectForDiveJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"array_objects_required": true, "array_strings_required": true, "object_field_required": true}
+	requiredFields := []string{"array_objects_required", "array_strings_required", "object_field_required"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
 	val, exists = obj["array_objects_optional"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveArrayObjectsOptionalJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field array_objects_optional is not valid")
-		}
+		errs.merge("array_objects_optional", ValidateComplexObjectForDiveArrayObjectsOptionalJSON(val))
 	}
 	val, exists = obj["array_objects_required"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveArrayObjectsRequiredJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field array_objects_required is not valid")
-		}
+		errs.merge("array_objects_required", ValidateComplexObjectForDiveArrayObjectsRequiredJSON(val))
 	}
 	val, exists = obj["object_field_optional"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveObjectFieldOptionalJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field object_field_optional is not valid")
-		}
+		errs.merge("object_field_optional", ValidateComplexObjectForDiveObjectFieldOptionalJSON(val))
 	}
 	val, exists = obj["object_field_required"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveObjectFieldRequiredJSON(val)
-		if err != nil {
-			return errors.Wrap(err, "field object_field_required is not valid")
-		}
+		errs.merge("object_field_required", ValidateComplexObjectForDiveObjectFieldRequiredJSON(val))
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateNewResourseResponseJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"count": true, "name": true, "param": true}
+	requiredFields := []string{"count", "name", "param"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 
 type ErrorHandler = func(w http.ResponseWriter, r *http.Request, status int, msg string)
@@ -549,3 +602,210 @@ func (h *Handler) SetErrorHandler(eh ErrorHandler) {
 var DefaultErrorHandler ErrorHandler = func(w http.ResponseWriter, r *http.Request, status int, msg string) {
 	http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(msg)), status)
 }
+var ErrNotImplemented = errors.New("not implemented")
+
+func jsonTagName(field reflect.StructField) string {
+	return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+}
+
+type ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError)
+
+func WithValidationErrorHandler(veh ValidationErrorHandler) Option {
+	return func(h *Handler) {
+		h.validationErrorHandler = veh
+	}
+}
+
+type ProblemDetails struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+var ProblemDetailsHandler ValidationErrorHandler = func(w http.ResponseWriter, r *http.Request, err *ValidationError) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(ProblemDetails{Type: "about:blank", Title: http.StatusText(http.StatusBadRequest), Status: http.StatusBadRequest, Detail: "request validation failed", Instance: r.URL.Path, Errors: err.Errors})
+}
+
+func (h *Handler) handleValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		h.errorHandler(w, r, http.StatusRequestEntityTooLarge, "Request Entity Too Large")
+		return
+	}
+	var validationError *ValidationError
+	if h.validationErrorHandler != nil && errors.As(err, &validationError) {
+		h.validationErrorHandler(w, r, validationError)
+		return
+	}
+	h.errorHandler(w, r, http.StatusBadRequest, err.Error())
+}
+
+type ResponseValidationError struct {
+	Operation  string
+	StatusCode int
+	Err        *ValidationError
+}
+
+func (e *ResponseValidationError) Error() string {
+	return fmt.Sprintf("%s: invalid %d response: %s", e.Operation, e.StatusCode, e.Err.Error())
+}
+func (e *ResponseValidationError) Unwrap() error {
+	return e.Err
+}
+func WithResponseValidation() Option {
+	return func(h *Handler) {
+		h.validateResponses = true
+	}
+}
+
+type OperationID string
+type operationIDKey struct{}
+
+func OperationIDFromContext(ctx context.Context) (OperationID, bool) {
+	id, ok := ctx.Value(operationIDKey{}).(OperationID)
+	return id, ok
+}
+func WithOperationMiddleware(id OperationID, mw ...func(http.Handler) http.Handler) Option {
+	return func(h *Handler) {
+		if h.middlewares == nil {
+			h.middlewares = make(map[OperationID][]func(http.Handler) http.Handler)
+		}
+		h.middlewares[id] = append(h.middlewares[id], mw...)
+	}
+}
+func (h *Handler) operationHandler(id OperationID, handle http.HandlerFunc) http.Handler {
+	var handler http.Handler = handle
+	middlewares := h.middlewares[id]
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), operationIDKey{}, id)))
+	})
+}
+
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+type ValidationError struct {
+	Errors []FieldError
+	causes []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, field := range e.Errors {
+		if field.Pointer == "" {
+			messages = append(messages, field.Message)
+			continue
+		}
+		messages = append(messages, field.Pointer+": "+field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+func (e *ValidationError) Unwrap() []error {
+	return e.causes
+}
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(tokens ...string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+		pointer.WriteString("/" + jsonPointerEscaper.Replace(token))
+	}
+	return pointer.String()
+}
+func (e *ValidationError) add(pointer string, rule string, message string) {
+	for _, field := range e.Errors {
+		if field.Pointer == pointer {
+			return
+		}
+	}
+	e.Errors = append(e.Errors, FieldError{Pointer: pointer, Rule: rule, Message: message})
+}
+func (e *ValidationError) addParam(name string, err error) {
+	var field *FieldError
+	if errors.As(err, &field) {
+		e.add(jsonPointer(name), field.Rule, field.Message)
+		return
+	}
+	e.add(jsonPointer(name), "type", err.Error())
+}
+func (e *ValidationError) merge(token string, err error) {
+	if err == nil {
+		return
+	}
+	prefix := jsonPointer(token)
+	var nested *ValidationError
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &nested):
+		for _, field := range nested.Errors {
+			e.add(prefix+field.Pointer, field.Rule, field.Message)
+		}
+		e.causes = append(e.causes, nested.causes...)
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			rule, message := validatorRule(fieldError)
+			e.add(prefix+namespacePointer(fieldError.Namespace()), rule, message)
+		}
+	case errors.As(err, &typeError):
+		pointer := prefix
+		if typeError.Field != "" {
+			pointer += jsonPointer(strings.Split(typeError.Field, ".")...)
+		}
+		e.add(pointer, "type", "value of type "+typeError.Value+" is not "+typeError.Type.String())
+	default:
+		e.add(prefix, "invalid", err.Error())
+		e.causes = append(e.causes, err)
+	}
+}
+func namespacePointer(namespace string) string {
+	if !strings.HasPrefix(namespace, "[") {
+		index := strings.IndexAny(namespace, ".[")
+		if index < 0 {
+			return ""
+		}
+		namespace = strings.TrimPrefix(namespace[index:], ".")
+	}
+	tokens := strings.FieldsFunc(namespace, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	})
+	filtered := tokens[:0]
+	for _, token := range tokens {
+		if token != "-" {
+			filtered = append(filtered, token)
+		}
+	}
+	return jsonPointer(filtered...)
+}
+func validatorRule(fieldError validator.FieldError) (string, string) {
+	rule := fieldError.Tag()
+	if fieldError.Param() != "" {
+		rule += "=" + fieldError.Param()
+	}
+	return fieldError.Tag(), "value must satisfy " + rule
+}
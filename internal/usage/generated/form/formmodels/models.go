@@ -0,0 +1,40 @@
+// Code generated by github.com/sintoniastrategy/validgo-gen; DO NOT EDIT.
+
+package formmodels
+
+type LegacyWebhookRequestBodyIds []int64
+type LegacyWebhookRequestBody struct {
+	Attempt *int32                       `json:"attempt,omitempty" validate:"omitempty,min=1"`
+	Event   *string                      `json:"event,omitempty" validate:"omitempty,oneof=created deleted"`
+	Ids     *LegacyWebhookRequestBodyIds `json:"ids,omitempty" validate:"omitempty,dive"`
+	Note    *string                      `json:"note,omitempty" validate:"omitempty"`
+	SentOn  *string                      `json:"sent_on,omitempty" validate:"omitempty"`
+}
+type LegacyWebhookRequest struct {
+	Body *LegacyWebhookRequestBody
+}
+type LegacyWebhookResponse204 struct {
+}
+type LegacyWebhookResponse struct {
+	StatusCode  int
+	Response204 *LegacyWebhookResponse204
+}
+type CreateTokenRequest struct {
+	Body TokenRequest
+}
+type CreateTokenResponse200 struct {
+	Body TokenRequest
+}
+type CreateTokenResponse struct {
+	StatusCode  int
+	Response200 *CreateTokenResponse200
+}
+type TokenRequestScope []string
+type TokenRequest struct {
+	ClientID     string             `json:"client_id"`
+	ClientSecret *string            `json:"client_secret,omitempty" validate:"omitempty,min=8"`
+	ExpiresIn    *float64           `json:"expires_in,omitempty" validate:"omitempty,max=86400"`
+	GrantType    string             `json:"grant_type" validate:"oneof=client_credentials password"`
+	Remember     *bool              `json:"remember,omitempty" validate:"omitempty"`
+	Scope        *TokenRequestScope `json:"scope,omitempty" validate:"omitempty,dive"`
+}
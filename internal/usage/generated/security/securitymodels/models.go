@@ -0,0 +1,51 @@
+// Code generated by github.com/sintoniastrategy/validgo-gen; DO NOT EDIT.
+
+package securitymodels
+
+type GetStatusRequest struct {
+}
+type GetStatusResponse200 struct {
+}
+type GetStatusResponse struct {
+	StatusCode  int
+	Response200 *GetStatusResponse200
+}
+type ListReportsRequest struct {
+}
+type ListReportsResponse200 struct {
+	Body Caller
+}
+type ListReportsResponse struct {
+	StatusCode  int
+	Response200 *ListReportsResponse200
+}
+type GetMeRequest struct {
+}
+type GetMeResponse200 struct {
+	Body Caller
+}
+type GetMeResponse struct {
+	StatusCode  int
+	Response200 *GetMeResponse200
+}
+type GetFeedRequest struct {
+}
+type GetFeedResponse200 struct {
+	Body Caller
+}
+type GetFeedResponse struct {
+	StatusCode  int
+	Response200 *GetFeedResponse200
+}
+type AdminActionRequest struct {
+}
+type AdminActionResponse200 struct {
+	Body Caller
+}
+type AdminActionResponse struct {
+	StatusCode  int
+	Response200 *AdminActionResponse200
+}
+type Caller struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,112 @@
+// Code generated by github.com/sintoniastrategy/validgo-gen; DO NOT EDIT.
+
+package uploadmodels
+
+import "io"
+
+type PostImageRequest struct {
+	ContentType string
+	JpegBody    *BinaryBody
+	PngBody     *BinaryBody
+}
+type PostImageResponse201 struct {
+	Body UploadResult
+}
+type PostImageResponse struct {
+	StatusCode  int
+	Response201 *PostImageResponse201
+}
+type StoreDocumentRequestBody struct {
+	Document *MultipartFile `json:"document,omitempty" validate:"omitempty"`
+}
+type StoreDocumentRequest struct {
+	Body *StoreDocumentRequestBody
+}
+type StoreDocumentResponse204 struct {
+}
+type StoreDocumentResponse struct {
+	StatusCode  int
+	Response204 *StoreDocumentResponse204
+}
+type UploadAvatarRequestBodyTags []string
+type UploadAvatarRequestBodyThumbnails []MultipartFile
+type UploadAvatarRequestBody struct {
+	Avatar     MultipartFile                      `json:"avatar"`
+	Caption    *string                            `json:"caption,omitempty" validate:"omitempty,max=20"`
+	Tags       *UploadAvatarRequestBodyTags       `json:"tags,omitempty" validate:"omitempty,dive"`
+	Thumbnails *UploadAvatarRequestBodyThumbnails `json:"thumbnails,omitempty" validate:"omitempty,dive"`
+	UserID     string                             `json:"user_id"`
+}
+type UploadAvatarRequest struct {
+	Body UploadAvatarRequestBody
+}
+type UploadAvatarResponse200 struct {
+	Body UploadResult
+}
+type UploadAvatarResponse struct {
+	StatusCode  int
+	Response200 *UploadAvatarResponse200
+}
+type GetImagePathParams struct {
+	ID int64 `json:"id"`
+}
+type GetImageRequest struct {
+	Path GetImagePathParams
+}
+type GetImageResponse200 struct {
+	Body BinaryBody
+}
+type GetImageResponse struct {
+	StatusCode  int
+	Response200 *GetImageResponse200
+}
+type GetFilePathParams struct {
+	Name string `json:"name"`
+}
+type GetFileRequest struct {
+	Path GetFilePathParams
+}
+type GetFileResponse200 struct {
+	JSONBody *UploadResult
+	RawBody  *BinaryBody
+}
+type GetFileResponse404 struct {
+}
+type GetFileResponse struct {
+	StatusCode  int
+	Response200 *GetFileResponse200
+	Response404 *GetFileResponse404
+}
+type PutFilePathParams struct {
+	Name string `json:"name"`
+}
+type PutFileRequest struct {
+	Path PutFilePathParams
+	Body BinaryBody
+}
+type PutFileResponse204 struct {
+}
+type PutFileResponse struct {
+	StatusCode  int
+	Response204 *PutFileResponse204
+}
+type UploadResultThumbnails []string
+type UploadResult struct {
+	Content     *string                 `json:"content,omitempty" validate:"omitempty"`
+	ContentType *string                 `json:"content_type,omitempty" validate:"omitempty"`
+	Filename    string                  `json:"filename"`
+	Size        int64                   `json:"size"`
+	Thumbnails  *UploadResultThumbnails `json:"thumbnails,omitempty" validate:"omitempty,dive"`
+}
+type BinaryBody struct {
+	ContentType   string
+	ContentLength int64
+	Filename      string
+	Content       io.ReadCloser
+}
+type MultipartFile struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
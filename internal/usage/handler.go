@@ -1,3 +1,3 @@
 package usage
 
-//go:generate go run ../../cmd/generate.go -d ./ -p github.com/sintoniastrategy/validgo-gen/internal/usage a_pi.yaml def.yml
+//go:generate go run ../../cmd/generate.go -mocks -impl ./impl -d ./ -p github.com/sintoniastrategy/validgo-gen/internal/usage a_pi.yaml def.yml params.yaml validation.yaml composition.yaml security.yaml form.yaml upload.yaml content.yaml
@@ -0,0 +1,18 @@
+// Package apiimpl implements the operations of a_pi.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package apiimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/api"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/api/apimodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// api.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleCreate(ctx context.Context, r apimodels.CreateRequest) (*apimodels.CreateResponse, error) {
+	return nil, api.ErrNotImplemented
+}
@@ -0,0 +1,26 @@
+// Package compositionimpl implements the operations of composition.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package compositionimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/composition"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/composition/compositionmodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// composition.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleCreateShape(ctx context.Context, r compositionmodels.CreateShapeRequest) (*compositionmodels.CreateShapeResponse, error) {
+	return nil, composition.ErrNotImplemented
+}
+
+func (h *Handler) HandleCreatePet(ctx context.Context, r compositionmodels.CreatePetRequest) (*compositionmodels.CreatePetResponse, error) {
+	return nil, composition.ErrNotImplemented
+}
+
+func (h *Handler) HandleCreateContact(ctx context.Context, r compositionmodels.CreateContactRequest) (*compositionmodels.CreateContactResponse, error) {
+	return nil, composition.ErrNotImplemented
+}
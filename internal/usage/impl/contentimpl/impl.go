@@ -0,0 +1,34 @@
+// Package contentimpl implements the operations of content.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package contentimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/content"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/content/contentmodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// content.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleCreateNote(ctx context.Context, r contentmodels.CreateNoteRequest) (*contentmodels.CreateNoteResponse, error) {
+	return nil, content.ErrNotImplemented
+}
+
+func (h *Handler) HandlePublishEvent(ctx context.Context, r contentmodels.PublishEventRequest) (*contentmodels.PublishEventResponse, error) {
+	return nil, content.ErrNotImplemented
+}
+
+func (h *Handler) HandleUpdateItem(ctx context.Context, r contentmodels.UpdateItemRequest) (*contentmodels.UpdateItemResponse, error) {
+	return nil, content.ErrNotImplemented
+}
+
+func (h *Handler) HandleListItems(ctx context.Context, r contentmodels.ListItemsRequest) (*contentmodels.ListItemsResponse, error) {
+	return nil, content.ErrNotImplemented
+}
+
+func (h *Handler) HandleDescribeItem(ctx context.Context, r contentmodels.DescribeItemRequest) (*contentmodels.DescribeItemResponse, error) {
+	return nil, content.ErrNotImplemented
+}
@@ -0,0 +1,22 @@
+// Package formimpl implements the operations of form.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package formimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/form"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/form/formmodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// form.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleLegacyWebhook(ctx context.Context, r formmodels.LegacyWebhookRequest) (*formmodels.LegacyWebhookResponse, error) {
+	return nil, form.ErrNotImplemented
+}
+
+func (h *Handler) HandleCreateToken(ctx context.Context, r formmodels.CreateTokenRequest) (*formmodels.CreateTokenResponse, error) {
+	return nil, form.ErrNotImplemented
+}
@@ -0,0 +1,26 @@
+// Package paramsimpl implements the operations of params.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package paramsimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params/paramsmodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// params.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleSearchItems(ctx context.Context, r paramsmodels.SearchItemsRequest) (*paramsmodels.SearchItemsResponse, error) {
+	return nil, params.ErrNotImplemented
+}
+
+func (h *Handler) HandleListItems(ctx context.Context, r paramsmodels.ListItemsRequest) (*paramsmodels.ListItemsResponse, error) {
+	return nil, params.ErrNotImplemented
+}
+
+func (h *Handler) HandleGetItem(ctx context.Context, r paramsmodels.GetItemRequest) (*paramsmodels.GetItemResponse, error) {
+	return nil, params.ErrNotImplemented
+}
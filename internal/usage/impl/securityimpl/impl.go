@@ -0,0 +1,34 @@
+// Package securityimpl implements the operations of security.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package securityimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/security"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/security/securitymodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// security.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleGetStatus(ctx context.Context, r securitymodels.GetStatusRequest) (*securitymodels.GetStatusResponse, error) {
+	return nil, security.ErrNotImplemented
+}
+
+func (h *Handler) HandleListReports(ctx context.Context, r securitymodels.ListReportsRequest) (*securitymodels.ListReportsResponse, error) {
+	return nil, security.ErrNotImplemented
+}
+
+func (h *Handler) HandleGetMe(ctx context.Context, r securitymodels.GetMeRequest) (*securitymodels.GetMeResponse, error) {
+	return nil, security.ErrNotImplemented
+}
+
+func (h *Handler) HandleGetFeed(ctx context.Context, r securitymodels.GetFeedRequest) (*securitymodels.GetFeedResponse, error) {
+	return nil, security.ErrNotImplemented
+}
+
+func (h *Handler) HandleAdminAction(ctx context.Context, r securitymodels.AdminActionRequest) (*securitymodels.AdminActionResponse, error) {
+	return nil, security.ErrNotImplemented
+}
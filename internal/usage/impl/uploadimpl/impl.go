@@ -0,0 +1,38 @@
+// Package uploadimpl implements the operations of upload.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package uploadimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/upload"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/upload/uploadmodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// upload.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleStoreDocument(ctx context.Context, r uploadmodels.StoreDocumentRequest) (*uploadmodels.StoreDocumentResponse, error) {
+	return nil, upload.ErrNotImplemented
+}
+
+func (h *Handler) HandleUploadAvatar(ctx context.Context, r uploadmodels.UploadAvatarRequest) (*uploadmodels.UploadAvatarResponse, error) {
+	return nil, upload.ErrNotImplemented
+}
+
+func (h *Handler) HandlePostImage(ctx context.Context, r uploadmodels.PostImageRequest) (*uploadmodels.PostImageResponse, error) {
+	return nil, upload.ErrNotImplemented
+}
+
+func (h *Handler) HandleGetImage(ctx context.Context, r uploadmodels.GetImageRequest) (*uploadmodels.GetImageResponse, error) {
+	return nil, upload.ErrNotImplemented
+}
+
+func (h *Handler) HandleGetFile(ctx context.Context, r uploadmodels.GetFileRequest) (*uploadmodels.GetFileResponse, error) {
+	return nil, upload.ErrNotImplemented
+}
+
+func (h *Handler) HandlePutFile(ctx context.Context, r uploadmodels.PutFileRequest) (*uploadmodels.PutFileResponse, error) {
+	return nil, upload.ErrNotImplemented
+}
@@ -0,0 +1,30 @@
+// Package validationimpl implements the operations of validation.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package validationimpl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/validation"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/validation/validationmodels"
+)
+
+// Handler implements the handler of every operation, wire it with
+// validation.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleCreateSignup(ctx context.Context, r validationmodels.CreateSignupRequest) (*validationmodels.CreateSignupResponse, error) {
+	return validation.CreateSignup501(), nil
+}
+
+func (h *Handler) HandlePutSettings(ctx context.Context, r validationmodels.PutSettingsRequest) (*validationmodels.PutSettingsResponse, error) {
+	return nil, validation.ErrNotImplemented
+}
+
+func (h *Handler) HandlePutLimits(ctx context.Context, r validationmodels.PutLimitsRequest) (*validationmodels.PutLimitsResponse, error) {
+	return nil, validation.ErrNotImplemented
+}
+
+func (h *Handler) HandlePutArticle(ctx context.Context, r validationmodels.PutArticleRequest) (*validationmodels.PutArticleResponse, error) {
+	return nil, validation.ErrNotImplemented
+}
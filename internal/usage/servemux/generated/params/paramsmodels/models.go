@@ -0,0 +1,94 @@
+// Code generated by github.com/sintoniastrategy/validgo-gen; DO NOT EDIT.
+
+package paramsmodels
+
+import (
+	"time"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type SearchItemsQueryParamsFilter struct {
+	MinRatio *float64 `json:"min_ratio,omitempty" validate:"omitempty"`
+	Owner    *string  `json:"owner,omitempty" validate:"omitempty"`
+	Status   string   `json:"status" validate:"oneof=open closed"`
+}
+type SearchItemsQueryParams struct {
+	Filter *SearchItemsQueryParamsFilter `json:"filter,omitempty" validate:"omitempty"`
+	Page   Page                          `json:"page"`
+}
+type SearchItemsRequest struct {
+	Query SearchItemsQueryParams
+}
+type SearchItemsResponse200 struct {
+	Body SearchEcho
+}
+type SearchItemsResponse struct {
+	StatusCode  int
+	Response200 *SearchItemsResponse200
+}
+type ListItemsQueryParams struct {
+	Tag    []string     `json:"tag" validate:"min=1,dive"`
+	Ids    *[]int64     `json:"ids,omitempty" validate:"omitempty,unique,dive"`
+	Ratios *[]float64   `json:"ratios,omitempty" validate:"omitempty,dive"`
+	Days   *[]time.Time `json:"days,omitempty" validate:"omitempty,max=2,dive"`
+}
+type ListItemsRequest struct {
+	Query ListItemsQueryParams
+}
+type ListItemsResponse200 struct {
+	Body ItemList
+}
+type ListItemsResponse struct {
+	StatusCode  int
+	Response200 *ListItemsResponse200
+}
+type GetItemPathParams struct {
+	ID     int64           `json:"id" validate:"min=1"`
+	UID    uuid.UUID       `json:"uid"`
+	Flag   bool            `json:"flag"`
+	Ratio  float64         `json:"ratio"`
+	Day    time.Time       `json:"day"`
+	At     time.Time       `json:"at"`
+	Amount decimal.Decimal `json:"amount"`
+}
+type GetItemRequest struct {
+	Path GetItemPathParams
+}
+type GetItemResponse200 struct {
+	Body Item
+}
+type GetItemResponse struct {
+	StatusCode  int
+	Response200 *GetItemResponse200
+}
+type Item struct {
+	Amount *decimal.Decimal `json:"amount,omitempty" validate:"omitempty"`
+	At     *time.Time       `json:"at,omitempty" validate:"omitempty"`
+	Day    *string          `json:"day,omitempty" validate:"omitempty"`
+	Flag   bool             `json:"flag"`
+	ID     int64            `json:"id"`
+	Ratio  *float64         `json:"ratio,omitempty" validate:"omitempty"`
+	UID    string           `json:"uid"`
+}
+type ItemListDays []string
+type ItemListIds []int64
+type ItemListRatios []float64
+type ItemListTags []string
+type ItemList struct {
+	Days   *ItemListDays   `json:"days,omitempty" validate:"omitempty,dive"`
+	Ids    *ItemListIds    `json:"ids,omitempty" validate:"omitempty,dive"`
+	Ratios *ItemListRatios `json:"ratios,omitempty" validate:"omitempty,dive"`
+	Tags   ItemListTags    `json:"tags" validate:"dive"`
+}
+type Page struct {
+	Offset *int `json:"offset,omitempty" validate:"omitempty,min=0"`
+	Size   int  `json:"size" validate:"min=1,max=50"`
+}
+type SearchEcho struct {
+	MinRatio *float64 `json:"min_ratio,omitempty" validate:"omitempty"`
+	Offset   *int     `json:"offset,omitempty" validate:"omitempty"`
+	Owner    *string  `json:"owner,omitempty" validate:"omitempty"`
+	Size     int      `json:"size"`
+	Status   *string  `json:"status,omitempty" validate:"omitempty"`
+}
@@ -0,0 +1,3 @@
+package servemux
+
+//go:generate go run ../../../cmd/generate.go -router servemux -mocks -d ./ -p github.com/sintoniastrategy/validgo-gen/internal/usage/servemux ../params.yaml
@@ -0,0 +1,3 @@
+package telemetry
+
+//go:generate go run ../../../cmd/generate.go -otel -mocks -d ./ -p github.com/sintoniastrategy/validgo-gen/internal/usage/telemetry ../validation.yaml
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/upload"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/upload/uploadmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestBinaryBody(t *testing.T) {
+	th := upload.NewTestHandler()
+	th.PutFile.Func = func(ctx context.Context, r uploadmodels.PutFileRequest) (*uploadmodels.PutFileResponse, error) {
+		content, err := io.ReadAll(r.Body.Content)
+		if err != nil {
+			return nil, err
+		}
+		if string(content) != "file bytes" {
+			return nil, assert.AnError
+		}
+		return upload.PutFile204(), nil
+	}
+	th.PostImage.Returns(upload.PostImage201(uploadmodels.UploadResult{Filename: "image"}))
+	server := httptest.NewServer(th)
+	defer server.Close()
+	client := upload.NewClient(server.URL)
+
+	get := func(path string, accept string) (*http.Response, string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		return resp, string(body)
+	}
+
+	t.Run("upload", func(t *testing.T) {
+		_, err := client.PutFile(context.Background(), uploadmodels.PutFileRequest{
+			Path: uploadmodels.PutFilePathParams{Name: "a.bin"},
+			Body: uploadmodels.BinaryBody{
+				ContentLength: 10,
+				Filename:      "a.bin",
+				Content:       io.NopCloser(strings.NewReader("file bytes")),
+			},
+		})
+		assert.NoError(t, err)
+
+		call := th.PutFile.Calls()[0]
+		assert.Equal(t, "application/octet-stream", call.Body.ContentType)
+		assert.Equal(t, int64(10), call.Body.ContentLength)
+		assert.Equal(t, "a.bin", call.Body.Filename)
+
+		req, err := http.NewRequest(http.MethodPut, server.URL+"/files/a.bin", strings.NewReader("text"))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "text/plain")
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	t.Run("optional upload", func(t *testing.T) {
+		_, err := client.PostImage(context.Background(), uploadmodels.PostImageRequest{
+			JpegBody: &uploadmodels.BinaryBody{Content: io.NopCloser(strings.NewReader("jpeg bytes"))},
+		})
+		assert.NoError(t, err)
+		call := th.PostImage.Calls()[0]
+		assert.Equal(t, "image/jpeg", call.ContentType)
+		assert.Equal(t, "image/jpeg", call.JpegBody.ContentType)
+		assert.Equal(t, int64(-1), call.JpegBody.ContentLength)
+		assert.Nil(t, call.PngBody)
+
+		resp, err := http.Post(server.URL+"/images", "image/png", http.NoBody)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Nil(t, th.PostImage.Calls()[1].PngBody)
+	})
+
+	t.Run("download", func(t *testing.T) {
+		content := &closeRecorder{Reader: strings.NewReader("png bytes")}
+		th.GetImage.Returns(upload.GetImage200(uploadmodels.BinaryBody{
+			ContentType:   "image/png",
+			ContentLength: 9,
+			Filename:      "me.png",
+			Content:       content,
+		}))
+		resp, body := get("/images/1", "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "9", resp.Header.Get("Content-Length"))
+		assert.Equal(t, `attachment; filename=me.png`, resp.Header.Get("Content-Disposition"))
+		assert.Equal(t, "png bytes", body)
+		assert.True(t, content.closed)
+
+		th.GetImage.Returns(upload.GetImage200(uploadmodels.BinaryBody{Content: io.NopCloser(strings.NewReader("?"))}))
+		resp, _ = get("/images/1", "")
+		assert.Equal(t, "application/octet-stream", resp.Header.Get("Content-Type"))
+		assert.Empty(t, resp.Header.Get("Content-Disposition"))
+	})
+
+	t.Run("negotiated download", func(t *testing.T) {
+		raw := uploadmodels.BinaryBody{Filename: "a.bin", Content: io.NopCloser(strings.NewReader("file bytes"))}
+		th.GetFile.Returns(&uploadmodels.GetFileResponse{
+			StatusCode:  http.StatusOK,
+			Response200: &uploadmodels.GetFileResponse200{RawBody: &raw},
+		})
+		response, err := client.GetFile(context.Background(), uploadmodels.GetFileRequest{
+			Path: uploadmodels.GetFilePathParams{Name: "a.bin"},
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, response.Response200.JSONBody)
+		body := response.Response200.RawBody
+		assert.Equal(t, "application/octet-stream", body.ContentType)
+		assert.Equal(t, int64(10), body.ContentLength)
+		assert.Equal(t, "a.bin", body.Filename)
+		content, err := io.ReadAll(body.Content)
+		assert.NoError(t, err)
+		assert.Equal(t, "file bytes", string(content))
+
+		unsent := &closeRecorder{Reader: strings.NewReader("file bytes")}
+		th.GetFile.Returns(&uploadmodels.GetFileResponse{
+			StatusCode:  http.StatusOK,
+			Response200: &uploadmodels.GetFileResponse200{RawBody: &uploadmodels.BinaryBody{Content: unsent}},
+		})
+		resp, _ := get("/files/a.bin", "application/json")
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+		assert.True(t, unsent.closed)
+	})
+
+	t.Run("500 closes the body returned with an error", func(t *testing.T) {
+		content := &closeRecorder{Reader: strings.NewReader("png bytes")}
+		th.GetImage.Func = func(ctx context.Context, r uploadmodels.GetImageRequest) (*uploadmodels.GetImageResponse, error) {
+			return upload.GetImage200(uploadmodels.BinaryBody{Content: content}), assert.AnError
+		}
+		resp, _ := get("/images/1", "")
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.True(t, content.closed)
+	})
+}
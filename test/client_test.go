@@ -0,0 +1,225 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/api"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/api/apimodels"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params/paramsmodels"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientParams(t *testing.T) {
+	server := newParamsServer()
+	defer server.Close()
+	client := params.NewClient(server.URL + "/")
+	ctx := context.Background()
+
+	t.Run("typed path params", func(t *testing.T) {
+		uid := uuid.MustParse("6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a")
+		at := time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)
+		response, err := client.GetItem(ctx, paramsmodels.GetItemRequest{
+			Path: paramsmodels.GetItemPathParams{
+				ID:     42,
+				UID:    uid,
+				Flag:   true,
+				Ratio:  1.5,
+				Day:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				At:     at,
+				Amount: decimal.RequireFromString("13.42"),
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		item := response.Response200.Body
+		assert.Equal(t, int64(42), item.ID)
+		assert.Equal(t, uid.String(), item.UID)
+		assert.True(t, item.Flag)
+		assert.Equal(t, 1.5, *item.Ratio)
+		assert.Equal(t, "2024-02-29", *item.Day)
+		assert.True(t, at.Equal(*item.At))
+		assert.Equal(t, "13.42", item.Amount.String())
+	})
+
+	t.Run("array query params", func(t *testing.T) {
+		ids := []int64{1, 2, 3}
+		ratios := []float64{0.5, 1.25}
+		days := []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+		response, err := client.ListItems(ctx, paramsmodels.ListItemsRequest{
+			Query: paramsmodels.ListItemsQueryParams{
+				Tag:    []string{"a b", "c|d"},
+				Ids:    &ids,
+				Ratios: &ratios,
+				Days:   &days,
+			},
+		})
+		assert.NoError(t, err)
+		list := response.Response200.Body
+		assert.Equal(t, paramsmodels.ItemListTags{"a b", "c|d"}, list.Tags)
+		assert.Equal(t, paramsmodels.ItemListIds(ids), *list.Ids)
+		assert.Equal(t, paramsmodels.ItemListRatios(ratios), *list.Ratios)
+		assert.Equal(t, paramsmodels.ItemListDays{"2024-01-02"}, *list.Days)
+	})
+
+	t.Run("object query params", func(t *testing.T) {
+		owner := "me"
+		offset := 10
+		response, err := client.SearchItems(ctx, paramsmodels.SearchItemsRequest{
+			Query: paramsmodels.SearchItemsQueryParams{
+				Filter: &paramsmodels.SearchItemsQueryParamsFilter{Status: "open", Owner: &owner},
+				Page:   paramsmodels.Page{Offset: &offset, Size: 20},
+			},
+		})
+		assert.NoError(t, err)
+		echo := response.Response200.Body
+		assert.Equal(t, "open", *echo.Status)
+		assert.Equal(t, "me", *echo.Owner)
+		assert.Nil(t, echo.MinRatio)
+		assert.Equal(t, 10, *echo.Offset)
+		assert.Equal(t, 20, echo.Size)
+	})
+}
+
+func TestClientHeadersCookiesAndBody(t *testing.T) {
+	router := chi.NewRouter()
+	api.NewHandler(&mockHandler{}).AddRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	var editedRequest *http.Request
+	client := api.NewClient(server.URL, api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		editedRequest = req
+		return nil
+	}))
+	optionalHeader := time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)
+	request := apimodels.CreateRequest{
+		Path:  apimodels.CreatePathParams{Param: "some value", Suffix: "es"},
+		Query: apimodels.CreateQueryParams{Count: "a&b"},
+		Headers: apimodels.CreateHeaders{
+			IdempotencyKey: "key",
+			OptionalHeader: &optionalHeader,
+		},
+		Cookies: apimodels.CreateCookies{RequiredCookieParam: "cookie-value"},
+		Body:    apimodels.CreateRequestBody{Name: "name"},
+	}
+
+	t.Run("200 with headers", func(t *testing.T) {
+		response, err := client.Create(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Nil(t, response.Response400)
+		assert.Equal(t, "name", response.Response200.Body.Name)
+		assert.Equal(t, "some value", response.Response200.Body.Param)
+		assert.Equal(t, "a&b", response.Response200.Body.Count)
+		assert.True(t, optionalHeader.Equal(*response.Response200.Body.Date2))
+		assert.Equal(t, "key", *response.Response200.Headers.IdempotencyKey)
+		assert.Equal(t, "application/json", editedRequest.Header.Get("Content-Type"))
+	})
+
+	t.Run("404 without body", func(t *testing.T) {
+		code := 404
+		notFound := request
+		notFound.Body.CodeForResponse = &code
+		response, err := client.Create(context.Background(), notFound)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		assert.NotNil(t, response.Response404)
+		assert.Nil(t, response.Response200)
+	})
+
+	t.Run("400 on invalid request", func(t *testing.T) {
+		invalid := request
+		invalid.Cookies.RequiredCookieParam = "short"
+		response, err := client.Create(context.Background(), invalid)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.NotNil(t, response.Response400)
+	})
+}
+
+func TestClientUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	client := params.NewClient(server.URL)
+	_, err := client.SearchItems(context.Background(), paramsmodels.SearchItemsRequest{
+		Query: paramsmodels.SearchItemsQueryParams{Page: paramsmodels.Page{Size: 1}},
+	})
+	var statusErr *params.UnexpectedStatusError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, "SearchItems", statusErr.Operation)
+	assert.Equal(t, http.StatusTeapot, statusErr.StatusCode)
+}
+
+func TestClientResponseValidation(t *testing.T) {
+	var responseBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(server.URL)
+	request := apimodels.CreateRequest{
+		Path:    apimodels.CreatePathParams{Param: "value", Suffix: "es"},
+		Query:   apimodels.CreateQueryParams{Count: "1"},
+		Headers: apimodels.CreateHeaders{IdempotencyKey: "key"},
+		Cookies: apimodels.CreateCookies{RequiredCookieParam: "cookie-value"},
+		Body:    apimodels.CreateRequestBody{Name: "name"},
+	}
+
+	t.Run("missing required fields", func(t *testing.T) {
+		responseBody = `{"name":"name","param":null}`
+		_, err := client.Create(context.Background(), request)
+		var responseErr *api.ResponseValidationError
+		assert.ErrorAs(t, err, &responseErr)
+		assert.Equal(t, "Create", responseErr.Operation)
+		assert.Equal(t, http.StatusOK, responseErr.StatusCode)
+		assert.Equal(t, []api.FieldError{
+			{Pointer: "/body/count", Rule: "required", Message: "field count is required"},
+			{Pointer: "/body/param", Rule: "null", Message: "field param cannot be null"},
+		}, responseErr.Err.Errors)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		responseBody = `{"name":`
+		_, err := client.Create(context.Background(), request)
+		var responseErr *api.ResponseValidationError
+		assert.ErrorAs(t, err, &responseErr)
+		assert.Equal(t, "/body", responseErr.Err.Errors[0].Pointer)
+	})
+
+	t.Run("valid body", func(t *testing.T) {
+		responseBody = `{"name":"name","param":"value","count":"1"}`
+		response, err := client.Create(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", response.Response200.Body.Param)
+	})
+}
+
+func TestDecodeResponseValidatesTags(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"a":1,"b":2,"c":3,"d":4}`)),
+	}
+	_, err := validation.DecodePutLimitsResponse(resp)
+	var responseErr *validation.ResponseValidationError
+	assert.ErrorAs(t, err, &responseErr)
+	assert.Equal(t, "PutLimits", responseErr.Operation)
+	assert.Equal(t, "/body", responseErr.Err.Errors[0].Pointer)
+	assert.Equal(t, "max", responseErr.Err.Errors[0].Rule)
+	assert.EqualError(t, err, "PutLimits: invalid 200 response: /body: value must satisfy max=3")
+}
@@ -0,0 +1,204 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/composition"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/composition/compositionmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+type compositionHandler struct{}
+
+func (c *compositionHandler) HandleCreatePet(ctx context.Context, r compositionmodels.CreatePetRequest) (*compositionmodels.CreatePetResponse, error) {
+	return composition.CreatePet200(r.Body), nil
+}
+
+func (c *compositionHandler) HandleCreateShape(ctx context.Context, r compositionmodels.CreateShapeRequest) (*compositionmodels.CreateShapeResponse, error) {
+	return composition.CreateShape200(r.Body), nil
+}
+
+func (c *compositionHandler) HandleCreateContact(ctx context.Context, r compositionmodels.CreateContactRequest) (*compositionmodels.CreateContactResponse, error) {
+	return composition.CreateContact200(r.Body), nil
+}
+
+func newCompositionServer() *httptest.Server {
+	router := chi.NewRouter()
+	h := &compositionHandler{}
+	composition.NewHandler(h, h, h).AddRoutes(router)
+	return httptest.NewServer(router)
+}
+
+func postJSON(t *testing.T, url string, body string) *http.Response {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	return resp
+}
+
+func TestAllOf(t *testing.T) {
+	server := newCompositionServer()
+	defer server.Close()
+
+	t.Run("200 merged", func(t *testing.T) {
+		resp := postJSON(t, server.URL+"/pets",
+			`{"id":7,"created_at":"2024-02-29T10:00:00Z","name":"Rex","nickname":null}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 7, body["id"])
+		assert.Equal(t, "2024-02-29T10:00:00Z", body["created_at"])
+		assert.Equal(t, "Rex", body["name"])
+	})
+
+	for _, tc := range []struct {
+		name string
+		body string
+	}{
+		{name: "400 base required", body: `{"name":"Rex","nickname":null}`},
+		{name: "400 member required", body: `{"id":7,"nickname":null}`},
+		{name: "400 own required", body: `{"id":7,"name":"Rex"}`},
+		{name: "400 base validator", body: `{"id":0,"name":"Rex","nickname":null}`},
+		{name: "400 member validator", body: `{"id":7,"name":"","nickname":null}`},
+		{name: "400 not nullable", body: `{"id":null,"name":"Rex","nickname":null}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := postJSON(t, server.URL+"/pets", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestOneOfDiscriminator(t *testing.T) {
+	server := newCompositionServer()
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name string
+		body string
+		want compositionmodels.ShapeVariant
+	}{
+		{
+			name: "200 circle",
+			body: `{"kind":"circle","radius":1.5}`,
+			want: compositionmodels.Circle{Kind: "circle", Radius: 1.5},
+		},
+		{
+			name: "200 square",
+			body: `{"kind":"square","side":2}`,
+			want: compositionmodels.Square{Kind: "square", Side: 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := postJSON(t, server.URL+"/shapes", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var body compositionmodels.Shape
+			err := json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, body.Value)
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		body string
+	}{
+		{name: "400 unknown discriminator", body: `{"kind":"triangle","side":2}`},
+		{name: "400 missing discriminator", body: `{"radius":1.5}`},
+		{name: "400 variant required", body: `{"kind":"circle","side":2}`},
+		{name: "400 variant validator", body: `{"kind":"square","side":0}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := postJSON(t, server.URL+"/shapes", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestOneOfWithoutDiscriminator(t *testing.T) {
+	server := newCompositionServer()
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name        string
+		body        string
+		wantContact compositionmodels.ContactVariant
+		wantLabel   compositionmodels.ContactEnvelopeLabelVariant
+	}{
+		{
+			name:        "200 email",
+			body:        `{"contact":{"email":"a@example.com"}}`,
+			wantContact: compositionmodels.EmailContact{Email: "a@example.com"},
+		},
+		{
+			name:        "200 phone with string label",
+			body:        `{"contact":{"phone":"+123456789"},"label":"home"}`,
+			wantContact: compositionmodels.PhoneContact{Phone: "+123456789"},
+			wantLabel:   compositionmodels.ContactEnvelopeLabelOption1("home"),
+		},
+		{
+			name:        "200 unknown property with integer label",
+			body:        `{"contact":{"email":"a@example.com","note":"x"},"label":3}`,
+			wantContact: compositionmodels.EmailContact{Email: "a@example.com"},
+			wantLabel:   compositionmodels.ContactEnvelopeLabelOption2(3),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := postJSON(t, server.URL+"/contacts", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var body compositionmodels.ContactEnvelope
+			err := json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantContact, body.Contact.Value)
+			if tc.wantLabel == nil {
+				assert.Nil(t, body.Label)
+			} else if assert.NotNil(t, body.Label) {
+				assert.Equal(t, tc.wantLabel, body.Label.Value)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		body string
+	}{
+		{name: "400 no variant", body: `{"contact":{"fax":"123"}}`},
+		{name: "400 several variants", body: `{"contact":{"email":"a@example.com","phone":"+123456789"}}`},
+		{name: "400 variant validator", body: `{"contact":{"email":"not-an-email"}}`},
+		{name: "400 variant pattern", body: `{"contact":{"phone":"123"}}`},
+		{name: "400 label no variant", body: `{"contact":{"email":"a@example.com"},"label":true}`},
+		{name: "400 alternate several variants", body: `{"contact":{"email":"a@example.com"},"alternates":[{"email":"a@example.com","phone":"+123456789"}]}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := postJSON(t, server.URL+"/contacts", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+
+	t.Run("400 alternate variant by index", func(t *testing.T) {
+		resp := postJSON(t, server.URL+"/contacts",
+			`{"contact":{"email":"a@example.com"},"alternates":[{"phone":"+123456789"},{"fax":"123"}]}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "/body/alternates/1: Contact: value matches none of the variants")
+	})
+}
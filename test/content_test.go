@@ -0,0 +1,288 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/content"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/content/contentmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestContentTypes(t *testing.T) {
+	th := content.NewTestHandler(content.WithValidationErrorHandler(content.ProblemDetailsHandler))
+	th.UpdateItem.Func = func(ctx context.Context, r contentmodels.UpdateItemRequest) (*contentmodels.UpdateItemResponse, error) {
+		item := contentmodels.Item{Name: "old", Price: 1}
+		switch r.ContentType {
+		case "application/json":
+			item = *r.JSONBody
+		case "application/merge-patch+json":
+			if r.MergePatchJSONBody.Name != nil {
+				item.Name = *r.MergePatchJSONBody.Name
+			}
+			if r.MergePatchJSONBody.Price != nil {
+				item.Price = *r.MergePatchJSONBody.Price
+			}
+		case "application/x-www-form-urlencoded":
+			item.Name = r.FormBody.Name
+		}
+		return content.UpdateItem200(item), nil
+	}
+	th.CreateNote.Returns(content.CreateNote204())
+	th.PublishEvent.Returns(content.PublishEvent202())
+	server := httptest.NewServer(th)
+	defer server.Close()
+	client := content.NewClient(server.URL)
+
+	send := func(method string, path string, contentType string, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		assert.NoError(t, err)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	t.Run("client round trip", func(t *testing.T) {
+		name := "patched"
+		for _, tc := range []struct {
+			request contentmodels.UpdateItemRequest
+			item    contentmodels.Item
+		}{
+			{
+				request: contentmodels.UpdateItemRequest{JSONBody: &contentmodels.Item{Name: "new", Price: 2}},
+				item:    contentmodels.Item{Name: "new", Price: 2},
+			},
+			{
+				request: contentmodels.UpdateItemRequest{MergePatchJSONBody: &contentmodels.ItemPatch{Name: &name}},
+				item:    contentmodels.Item{Name: "patched", Price: 1},
+			},
+			{
+				request: contentmodels.UpdateItemRequest{FormBody: &contentmodels.UpdateItemFormRequestBody{Name: "form"}},
+				item:    contentmodels.Item{Name: "form", Price: 1},
+			},
+		} {
+			tc.request.Path.ID = 7
+			response, err := client.UpdateItem(context.Background(), tc.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.item, response.Response200.Body)
+		}
+
+		calls := th.UpdateItem.Calls()
+		assert.Equal(t, "application/json", calls[0].ContentType)
+		assert.Nil(t, calls[0].MergePatchJSONBody)
+		assert.Equal(t, "application/merge-patch+json", calls[1].ContentType)
+		assert.Equal(t, "application/x-www-form-urlencoded", calls[2].ContentType)
+		assert.Nil(t, calls[2].JSONBody)
+	})
+
+	t.Run("multipart or JSON", func(t *testing.T) {
+		_, err := client.CreateNote(context.Background(), contentmodels.CreateNoteRequest{
+			MultipartBody: &contentmodels.CreateNoteMultipartRequestBody{
+				Text: "see attached",
+				Attachment: &contentmodels.MultipartFile{
+					Filename: "a.txt",
+					Content:  io.NopCloser(strings.NewReader("attached")),
+				},
+			},
+		})
+		assert.NoError(t, err)
+		_, err = client.CreateNote(context.Background(), contentmodels.CreateNoteRequest{
+			JSONBody: &contentmodels.Note{Text: "plain"},
+		})
+		assert.NoError(t, err)
+
+		calls := th.CreateNote.Calls()
+		assert.Equal(t, "multipart/form-data", calls[0].ContentType)
+		assert.Equal(t, "a.txt", calls[0].MultipartBody.Attachment.Filename)
+		assert.Equal(t, "application/json", calls[1].ContentType)
+		assert.Equal(t, "plain", calls[1].JSONBody.Text)
+	})
+
+	t.Run("vendor JSON type", func(t *testing.T) {
+		_, err := client.PublishEvent(context.Background(), contentmodels.PublishEventRequest{
+			Body: contentmodels.Event{ID: "1", Type: "created"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "created", th.PublishEvent.Calls()[0].Body.Type)
+
+		resp := send(http.MethodPost, "/events", "application/cloudevents+json; charset=utf-8", `{"id":"2","type":"deleted"}`)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		resp = send(http.MethodPost, "/events", "application/json", `{"id":"3","type":"deleted"}`)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+		// without Content-Type, the body is read as the vendor type
+		resp = send(http.MethodPost, "/events", "", `{"id":"4","type":"archived"}`)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		calls := th.PublishEvent.Calls()
+		assert.Equal(t, "archived", calls[len(calls)-1].Body.Type)
+	})
+
+	t.Run("JSON without content type", func(t *testing.T) {
+		resp := send(http.MethodPatch, "/items/7", "", `{"name":"bare","price":3}`)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		call := th.UpdateItem.Calls()[len(th.UpdateItem.Calls())-1]
+		assert.Equal(t, "application/json", call.ContentType)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		resp := send(http.MethodPatch, "/items/7", "text/plain", "name")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	for _, tc := range []struct {
+		name        string
+		contentType string
+		body        string
+		errors      []content.FieldError
+	}{
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			body:        `{"name":""}`,
+			errors: []content.FieldError{
+				{Pointer: "/body/price", Rule: "required", Message: "field price is required"},
+			},
+		},
+		{
+			name:        "merge patch",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":""}`,
+			errors: []content.FieldError{
+				{Pointer: "/body/name", Rule: "min", Message: "value must satisfy min=1"},
+			},
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "price=cheap",
+			errors: []content.FieldError{
+				{Pointer: "/body/name", Rule: "required", Message: "field name is required"},
+				{Pointer: "/body/price", Rule: "type", Message: "Price is not a valid number: strconv.ParseFloat: parsing \"cheap\": invalid syntax"},
+			},
+		},
+	} {
+		t.Run(tc.name+" validation", func(t *testing.T) {
+			resp := send(http.MethodPatch, "/items/7", tc.contentType, tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var problem content.ProblemDetails
+			err := json.NewDecoder(resp.Body).Decode(&problem)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.errors, problem.Errors)
+		})
+	}
+}
+
+func TestResponseContentTypes(t *testing.T) {
+	th := content.NewTestHandler(content.WithResponseValidation())
+	items := contentmodels.ListItemsResponse200JSONBody{{Name: "pen", Price: 2}}
+	xml := contentmodels.ListItemsResponse200XMLBody("<items><item>pen</item></items>")
+	csv := contentmodels.ListItemsResponse200CSVBody("name,price\npen,2\n")
+	th.ListItems.Returns(content.ListItems200(&items, &xml, &csv,
+		contentmodels.ListItemsResponse200Headers{XTotalCount: "1"},
+	))
+	th.DescribeItem.Returns(content.DescribeItem200("a pen"))
+	server := httptest.NewServer(th)
+	defer server.Close()
+
+	get := func(path string, accept string) (*http.Response, string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		return resp, string(body)
+	}
+
+	for _, tc := range []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{accept: "", contentType: "application/json; charset=utf-8", body: `[{"name":"pen","price":2}]` + "\n"},
+		{accept: "text/csv", contentType: "text/csv; charset=utf-8", body: "name,price\npen,2\n"},
+		{accept: "application/xml;q=0.5, text/*", contentType: "text/csv; charset=utf-8", body: "name,price\npen,2\n"},
+		{accept: "*/*;q=0.1, application/xml", contentType: "application/xml; charset=utf-8", body: "<items><item>pen</item></items>"},
+		{accept: "application/json;q=0, */*", contentType: "application/xml; charset=utf-8", body: "<items><item>pen</item></items>"},
+	} {
+		t.Run("accept "+tc.accept, func(t *testing.T) {
+			resp, body := get("/items", tc.accept)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tc.contentType, resp.Header.Get("Content-Type"))
+			assert.Equal(t, "Accept", resp.Header.Get("Vary"))
+			assert.Equal(t, "1", resp.Header.Get("X-Total-Count"))
+			assert.Equal(t, tc.body, body)
+		})
+	}
+
+	t.Run("not acceptable", func(t *testing.T) {
+		resp, _ := get("/items", "image/png, text/csv;q=0")
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("X-Total-Count"))
+	})
+
+	t.Run("only the bodies set are offered", func(t *testing.T) {
+		csv := contentmodels.ListItemsResponse200CSVBody("name,price\n")
+		th.ListItems.Returns(content.ListItems200(nil, nil, &csv, contentmodels.ListItemsResponse200Headers{XTotalCount: "0"}))
+		resp, _ := get("/items", "application/json")
+		assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+		resp, body := get("/items", "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "name,price\n", body)
+	})
+
+	t.Run("client reads the negotiated body", func(t *testing.T) {
+		xml := contentmodels.ListItemsResponse200XMLBody("<items/>")
+		th.ListItems.Returns(content.ListItems200(&items, &xml, &csv,
+			contentmodels.ListItemsResponse200Headers{XTotalCount: "1"},
+		))
+		response, err := content.NewClient(server.URL).ListItems(context.Background(), contentmodels.ListItemsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, items, *response.Response200.JSONBody)
+		assert.Nil(t, response.Response200.CSVBody)
+
+		csvClient := content.NewClient(server.URL, content.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Accept", "text/csv")
+			return nil
+		}))
+		response, err = csvClient.ListItems(context.Background(), contentmodels.ListItemsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, contentmodels.ListItemsResponse200CSVBody("name,price\npen,2\n"), *response.Response200.CSVBody)
+		assert.Nil(t, response.Response200.JSONBody)
+		assert.Equal(t, "1", response.Response200.Headers.XTotalCount)
+	})
+
+	t.Run("plain text body", func(t *testing.T) {
+		response, err := content.NewClient(server.URL).DescribeItem(context.Background(),
+			contentmodels.DescribeItemRequest{Path: contentmodels.DescribeItemPathParams{ID: 1}},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, contentmodels.DescribeItemResponse200Body("a pen"), response.Response200.Body)
+
+		th.DescribeItem.Returns(content.DescribeItem200(contentmodels.DescribeItemResponse200Body(strings.Repeat("a", 41))))
+		resp, _ := get("/items/1", "")
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/form"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/form/formmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormBody(t *testing.T) {
+	th := form.NewTestHandler(form.WithValidationErrorHandler(form.ProblemDetailsHandler), form.WithFormLimit(1<<10))
+	th.CreateToken.Func = func(ctx context.Context, r formmodels.CreateTokenRequest) (*formmodels.CreateTokenResponse, error) {
+		return form.CreateToken200(r.Body), nil
+	}
+	th.LegacyWebhook.Returns(form.LegacyWebhook204())
+	server := httptest.NewServer(th)
+	defer server.Close()
+
+	post := func(path string, contentType string, body string) *http.Response {
+		t.Helper()
+		resp, err := http.Post(server.URL+path, contentType, strings.NewReader(body))
+		assert.NoError(t, err)
+		return resp
+	}
+
+	t.Run("client round trip", func(t *testing.T) {
+		secret := "s3cr3t-value"
+		scope := formmodels.TokenRequestScope{"read", "write"}
+		remember := true
+		request := formmodels.TokenRequest{
+			GrantType:    "client_credentials",
+			ClientID:     "8a5b7c1e-3f0d-4a57-9a9e-2b7d4c6e8f10",
+			ClientSecret: &secret,
+			Scope:        &scope,
+			Remember:     &remember,
+		}
+		response, err := form.NewClient(server.URL).CreateToken(context.Background(), formmodels.CreateTokenRequest{Body: request})
+		assert.NoError(t, err)
+		assert.Equal(t, request, response.Response200.Body)
+	})
+
+	t.Run("space delimited scope", func(t *testing.T) {
+		resp := post("/oauth/token", "application/x-www-form-urlencoded",
+			"grant_type=password&client_id=8a5b7c1e-3f0d-4a57-9a9e-2b7d4c6e8f10&scope=a+b+c&expires_in=60")
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		call := th.CreateToken.Calls()[len(th.CreateToken.Calls())-1]
+		assert.Equal(t, formmodels.TokenRequestScope{"a", "b", "c"}, *call.Body.Scope)
+		assert.InDelta(t, 60, *call.Body.ExpiresIn, 0)
+	})
+
+	t.Run("optional body", func(t *testing.T) {
+		resp := post("/webhooks/legacy", "application/x-www-form-urlencoded", "")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Nil(t, th.LegacyWebhook.Calls()[0].Body)
+
+		resp = post("/webhooks/legacy", "application/x-www-form-urlencoded; charset=utf-8",
+			"event=created&attempt=2&ids=1&ids=2&sent_on=2024-05-01&note=")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		body := th.LegacyWebhook.Calls()[1].Body
+		assert.Equal(t, "created", *body.Event)
+		assert.Equal(t, int32(2), *body.Attempt)
+		assert.Equal(t, formmodels.LegacyWebhookRequestBodyIds{1, 2}, *body.Ids)
+		assert.Equal(t, "2024-05-01", *body.SentOn)
+		assert.Nil(t, body.Note)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		resp := post("/oauth/token", "application/json", `{"grant_type":"password"}`)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	t.Run("413 too large", func(t *testing.T) {
+		resp := post("/webhooks/legacy", "application/x-www-form-urlencoded", "note="+strings.Repeat("x", 2<<10))
+		resp.Body.Close()
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		path   string
+		body   string
+		errors []form.FieldError
+	}{
+		{
+			name: "required and null",
+			path: "/oauth/token",
+			body: "client_id=&scope=read",
+			errors: []form.FieldError{
+				{Pointer: "/body/client_id", Rule: "null", Message: "field client_id cannot be null"},
+				{Pointer: "/body/grant_type", Rule: "required", Message: "field grant_type is required"},
+			},
+		},
+		{
+			name: "types and validators",
+			path: "/oauth/token",
+			body: "grant_type=implicit&client_id=42&client_secret=short&remember=maybe&expires_in=90000",
+			errors: []form.FieldError{
+				{Pointer: "/body/remember", Rule: "type", Message: "Remember is not a valid boolean: strconv.ParseBool: parsing \"maybe\": invalid syntax"},
+				{Pointer: "/body/grant_type", Rule: "oneof", Message: "value must satisfy oneof=client_credentials password"},
+				{Pointer: "/body/client_secret", Rule: "min", Message: "value must satisfy min=8"},
+				{Pointer: "/body/expires_in", Rule: "max", Message: "value must satisfy max=86400"},
+			},
+		},
+		{
+			name: "array items",
+			path: "/webhooks/legacy",
+			body: "ids=1&ids=two&attempt=0",
+			errors: []form.FieldError{
+				{Pointer: "/body/ids", Rule: "type", Message: "Ids item is not a valid integer: strconv.ParseInt: parsing \"two\": invalid syntax"},
+				{Pointer: "/body/attempt", Rule: "min", Message: "value must satisfy min=1"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := post(tc.path, "application/x-www-form-urlencoded", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var problem form.ProblemDetails
+			err := json.NewDecoder(resp.Body).Decode(&problem)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.errors, problem.Errors)
+		})
+	}
+}
@@ -26,6 +26,7 @@ func TestGeneratorCreatesFiles(t *testing.T) {
 			"yamls/def.yaml",
 		},
 		RequiredFieldsArePointers: false,
+		Mocks:                     true,
 	}
 
 	// Run the generator
@@ -37,12 +38,20 @@ func TestGeneratorCreatesFiles(t *testing.T) {
 	// Check that files are created
 	expectedFiles := []string{
 		"generated/api/handlers.go",
+		"generated/api/client.go",
+		"generated/api/mocks.go",
 		"generated/api/apimodels/models.go",
 		"generated/api2/handlers.go",
+		"generated/api2/client.go",
+		"generated/api2/mocks.go",
 		"generated/api2/api2models/models.go",
 		"generated/api3/handlers.go",
+		"generated/api3/client.go",
+		"generated/api3/mocks.go",
 		"generated/api3/api3models/models.go",
 		"generated/api4/handlers.go",
+		"generated/api4/client.go",
+		"generated/api4/mocks.go",
 		"generated/api4/api4models/models.go",
 		"generated/def/handlers.go",
 		"generated/def/defmodels/models.go",
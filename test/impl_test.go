@@ -0,0 +1,103 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sebdah/goldie/v2"
+	"github.com/sintoniastrategy/validgo-gen/internal/generator"
+	"github.com/sintoniastrategy/validgo-gen/internal/generator/options"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/api"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/impl/apiimpl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplScaffold(t *testing.T) {
+	tmpDir := t.TempDir()
+	generate := func() []byte {
+		t.Helper()
+		gen := generator.NewGenerator(&options.Options{
+			DirPrefix:     tmpDir,
+			PackagePrefix: "github.com/sintoniastrategy/validgo-gen/test/testdata",
+			YAMLFiles:     []string{"yamls/api3.yaml"},
+			ImplDir:       filepath.Join(tmpDir, "impl"),
+		})
+		err := gen.Generate(context.Background())
+		assert.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(tmpDir, "impl", "api3impl", "impl.go"))
+		assert.NoError(t, err)
+		return content
+	}
+
+	t.Run("new file", func(t *testing.T) {
+		g := goldie.New(t, goldie.WithNameSuffix(""))
+		g.Assert(t, "impl/api3impl/impl.go", generate())
+	})
+
+	t.Run("existing file", func(t *testing.T) {
+		existing := `package api3impl
+
+import (
+	"context"
+
+	models "github.com/sintoniastrategy/validgo-gen/test/testdata/generated/api3/api3models"
+)
+
+type Service struct{}
+
+func (s *Service) HandleListResources(ctx context.Context, r models.ListResourcesRequest) (*models.ListResourcesResponse, error) {
+	return &models.ListResourcesResponse{}, nil
+}
+`
+		err := os.WriteFile(filepath.Join(tmpDir, "impl", "api3impl", "impl.go"), []byte(existing), 0o600)
+		assert.NoError(t, err)
+
+		expected := `package api3impl
+
+import (
+	"context"
+
+	models "github.com/sintoniastrategy/validgo-gen/test/testdata/generated/api3/api3models"
+)
+
+import (
+	"github.com/sintoniastrategy/validgo-gen/test/testdata/generated/api3"
+)
+
+type Service struct{}
+
+func (s *Service) HandleListResources(ctx context.Context, r models.ListResourcesRequest) (*models.ListResourcesResponse, error) {
+	return &models.ListResourcesResponse{}, nil
+}
+
+func (s *Service) HandleDeleteResource(ctx context.Context, r models.DeleteResourceRequest) (*models.DeleteResourceResponse, error) {
+	return nil, api3.ErrNotImplemented
+}
+`
+		assert.Equal(t, expected, string(generate()))
+		assert.Equal(t, expected, string(generate()))
+	})
+}
+
+func TestImplStub(t *testing.T) {
+	router := chi.NewRouter()
+	api.NewHandler(&apiimpl.Handler{}).AddRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourses?count=3", bytes.NewBufferString(`{"name": "value"}`))
+	assert.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+	request.Header.Set("Cookie", "required-cookie-param=required-value")
+	resp, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/api"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/api/apimodels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestHandler(t *testing.T) {
+	th := api.NewTestHandler()
+	router := chi.NewRouter()
+	th.AddRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	client := api.NewClient(server.URL)
+	request := apimodels.CreateRequest{
+		Path:    apimodels.CreatePathParams{Param: "value", Suffix: "es"},
+		Query:   apimodels.CreateQueryParams{Count: "1"},
+		Headers: apimodels.CreateHeaders{IdempotencyKey: "key"},
+		Cookies: apimodels.CreateCookies{RequiredCookieParam: "cookie-value"},
+		Body:    apimodels.CreateRequestBody{Name: "name"},
+	}
+
+	t.Run("500 when not mocked", func(t *testing.T) {
+		_, err := th.Create.HandleCreate(context.Background(), request)
+		assert.EqualError(t, err, "HandleCreate is not mocked")
+
+		response, err := client.Create(context.Background(), request)
+		assert.Nil(t, response)
+		var statusErr *api.UnexpectedStatusError
+		assert.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+	})
+
+	t.Run("canned response", func(t *testing.T) {
+		th.Create.Returns(api.Create404())
+		response, err := client.Create(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		th.Create.ReturnsError(errors.New("boom"))
+		_, err := client.Create(context.Background(), request)
+		var statusErr *api.UnexpectedStatusError
+		assert.ErrorAs(t, err, &statusErr)
+	})
+
+	t.Run("func", func(t *testing.T) {
+		th.Create.Func = func(ctx context.Context, r apimodels.CreateRequest) (*apimodels.CreateResponse, error) {
+			return api.Create200(
+				apimodels.NewResourseResponse{Name: r.Body.Name, Param: r.Path.Param, Count: r.Query.Count},
+				apimodels.CreateResponse200Headers{},
+			), nil
+		}
+		response, err := client.Create(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", response.Response200.Body.Param)
+	})
+
+	calls := th.Create.Calls()
+	assert.Len(t, calls, 5)
+	assert.Equal(t, "name", calls[4].Body.Name)
+	assert.Equal(t, "cookie-value", calls[4].Cookies.RequiredCookieParam)
+}
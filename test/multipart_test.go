@@ -0,0 +1,243 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/upload"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/upload/uploadmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+type multipartPart struct {
+	name        string
+	filename    string
+	contentType string
+	content     string
+}
+
+func multipartRequest(t *testing.T, parts ...multipartPart) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range parts {
+		header := make(textproto.MIMEHeader)
+		disposition := `form-data; name="` + part.name + `"`
+		if part.filename != "" {
+			disposition += `; filename="` + part.filename + `"`
+		}
+		header.Set("Content-Disposition", disposition)
+		if part.contentType != "" {
+			header.Set("Content-Type", part.contentType)
+		}
+		w, err := mw.CreatePart(header)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(part.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, mw.Close())
+	return &body, mw.FormDataContentType()
+}
+
+func TestMultipartBody(t *testing.T) {
+	th := upload.NewTestHandler(
+		upload.WithValidationErrorHandler(upload.ProblemDetailsHandler),
+		upload.WithMultipartLimits(1<<10, 4<<10),
+	)
+	th.UploadAvatar.Func = func(ctx context.Context, r uploadmodels.UploadAvatarRequest) (*uploadmodels.UploadAvatarResponse, error) {
+		defer r.Body.Avatar.Content.Close()
+		content, err := io.ReadAll(r.Body.Avatar.Content)
+		if err != nil {
+			return nil, err
+		}
+		contentType := r.Body.Avatar.ContentType
+		text := string(content)
+		var thumbnails uploadmodels.UploadResultThumbnails
+		if r.Body.Thumbnails != nil {
+			for _, thumbnail := range *r.Body.Thumbnails {
+				thumbnail.Content.Close()
+				thumbnails = append(thumbnails, thumbnail.Filename)
+			}
+		}
+		return upload.UploadAvatar200(uploadmodels.UploadResult{
+			Filename:    r.Body.Avatar.Filename,
+			ContentType: &contentType,
+			Size:        r.Body.Avatar.Size,
+			Content:     &text,
+			Thumbnails:  &thumbnails,
+		}), nil
+	}
+	th.StoreDocument.Returns(upload.StoreDocument204())
+	server := httptest.NewServer(th)
+	defer server.Close()
+
+	userID := "8a5b7c1e-3f0d-4a57-9a9e-2b7d4c6e8f10"
+
+	t.Run("client round trip", func(t *testing.T) {
+		caption := "me"
+		tags := uploadmodels.UploadAvatarRequestBodyTags{"a", "b"}
+		thumbnails := uploadmodels.UploadAvatarRequestBodyThumbnails{
+			{Filename: "small.png", ContentType: "image/png", Content: io.NopCloser(strings.NewReader("s"))},
+			{Filename: "tiny.gif", ContentType: "image/gif", Content: io.NopCloser(strings.NewReader("t"))},
+		}
+		response, err := upload.NewClient(server.URL).UploadAvatar(context.Background(), uploadmodels.UploadAvatarRequest{
+			Body: uploadmodels.UploadAvatarRequestBody{
+				UserID:  userID,
+				Caption: &caption,
+				Tags:    &tags,
+				Avatar: uploadmodels.MultipartFile{
+					Filename:    "me.png",
+					ContentType: "image/png",
+					Content:     io.NopCloser(strings.NewReader("png bytes")),
+				},
+				Thumbnails: &thumbnails,
+			},
+		})
+		assert.NoError(t, err)
+		result := response.Response200.Body
+		assert.Equal(t, "me.png", result.Filename)
+		assert.Equal(t, "image/png", *result.ContentType)
+		assert.Equal(t, int64(9), result.Size)
+		assert.Equal(t, "png bytes", *result.Content)
+		assert.Equal(t, uploadmodels.UploadResultThumbnails{"small.png", "tiny.gif"}, *result.Thumbnails)
+
+		call := th.UploadAvatar.Calls()[len(th.UploadAvatar.Calls())-1]
+		assert.Equal(t, userID, call.Body.UserID)
+		assert.Equal(t, "me", *call.Body.Caption)
+		assert.Equal(t, tags, *call.Body.Tags)
+	})
+
+	t.Run("optional body", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPut, server.URL+"/documents", http.NoBody)
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Nil(t, th.StoreDocument.Calls()[0].Body)
+
+		body, contentType := multipartRequest(t, multipartPart{
+			name: "document", filename: "a.pdf", contentType: "application/pdf", content: "%PDF",
+		})
+		req, err = http.NewRequest(http.MethodPut, server.URL+"/documents", body)
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		resp, err = http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "a.pdf", th.StoreDocument.Calls()[1].Body.Document.Filename)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		parts  []multipartPart
+		errors []upload.FieldError
+	}{
+		{
+			name: "required",
+			parts: []multipartPart{
+				{name: "caption", content: "a caption longer than twenty characters"},
+			},
+			errors: []upload.FieldError{
+				{Pointer: "/body/avatar", Rule: "required", Message: "field avatar is required"},
+				{Pointer: "/body/user_id", Rule: "required", Message: "field user_id is required"},
+				{Pointer: "/body/caption", Rule: "max", Message: "value must satisfy max=20"},
+			},
+		},
+		{
+			name: "content types",
+			parts: []multipartPart{
+				{name: "user_id", content: "42"},
+				{name: "avatar", filename: "me.txt", contentType: "text/plain", content: "text"},
+				{name: "thumbnails", filename: "small.png", contentType: "image/png", content: "s"},
+				{name: "thumbnails", filename: "small.pdf", contentType: "application/pdf", content: "p"},
+			},
+			errors: []upload.FieldError{
+				{Pointer: "/body/avatar", Rule: "contentType", Message: "content type text/plain is not one of image/png, image/jpeg"},
+				{Pointer: "/body/thumbnails", Rule: "contentType", Message: "content type application/pdf is not one of image/*"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body, contentType := multipartRequest(t, tc.parts...)
+			resp, err := http.Post(server.URL+"/avatars", contentType, body)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var problem upload.ProblemDetails
+			err = json.NewDecoder(resp.Body).Decode(&problem)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.errors, problem.Errors)
+		})
+	}
+
+	t.Run("400 closes the opened files", func(t *testing.T) {
+		if _, err := os.Stat("/proc/self/fd"); err != nil {
+			t.Skip("open files are listed in /proc/self/fd")
+		}
+		// past the 1 KiB kept in memory, the avatar is stored in a file
+		body, contentType := multipartRequest(t,
+			multipartPart{name: "user_id", content: userID},
+			multipartPart{name: "avatar", filename: "me.png", contentType: "image/png", content: strings.Repeat("x", 2<<10)},
+			multipartPart{name: "thumbnails", filename: "small.pdf", contentType: "application/pdf", content: "p"},
+		)
+		resp, err := http.Post(server.URL+"/avatars", contentType, body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assertNoMultipartFiles(t)
+	})
+
+	t.Run("204 closes the files left open", func(t *testing.T) {
+		if _, err := os.Stat("/proc/self/fd"); err != nil {
+			t.Skip("open files are listed in /proc/self/fd")
+		}
+		// the StoreDocument handler neither reads nor closes the document
+		body, contentType := multipartRequest(t, multipartPart{
+			name: "document", filename: "a.pdf", contentType: "application/pdf", content: strings.Repeat("x", 2<<10),
+		})
+		req, err := http.NewRequest(http.MethodPut, server.URL+"/documents", body)
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assertNoMultipartFiles(t)
+	})
+
+	t.Run("413 too large", func(t *testing.T) {
+		body, contentType := multipartRequest(t,
+			multipartPart{name: "user_id", content: userID},
+			multipartPart{name: "avatar", filename: "me.png", contentType: "image/png", content: strings.Repeat("x", 8<<10)},
+		)
+		resp, err := http.Post(server.URL+"/avatars", contentType, body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+}
+
+// assertNoMultipartFiles checks that no file of a multipart/form-data body
+// stored on disk is still open.
+func assertNoMultipartFiles(t *testing.T) {
+	t.Helper()
+	fds, err := os.ReadDir("/proc/self/fd")
+	assert.NoError(t, err)
+	for _, fd := range fds {
+		target, _ := os.Readlink("/proc/self/fd/" + fd.Name())
+		assert.NotContains(t, target, "multipart-")
+	}
+}
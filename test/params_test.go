@@ -0,0 +1,250 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params/paramsmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+type paramsHandler struct{}
+
+func (p *paramsHandler) HandleGetItem(ctx context.Context, r paramsmodels.GetItemRequest) (*paramsmodels.GetItemResponse, error) {
+	day := r.Path.Day.Format(time.DateOnly)
+	return params.GetItem200(paramsmodels.Item{
+		ID:     r.Path.ID,
+		UID:    r.Path.UID.String(),
+		Flag:   r.Path.Flag,
+		Ratio:  &r.Path.Ratio,
+		Day:    &day,
+		At:     &r.Path.At,
+		Amount: &r.Path.Amount,
+	}), nil
+}
+
+func (p *paramsHandler) HandleListItems(ctx context.Context, r paramsmodels.ListItemsRequest) (*paramsmodels.ListItemsResponse, error) {
+	list := paramsmodels.ItemList{Tags: r.Query.Tag}
+	if r.Query.Ids != nil {
+		ids := paramsmodels.ItemListIds(*r.Query.Ids)
+		list.Ids = &ids
+	}
+	if r.Query.Ratios != nil {
+		ratios := paramsmodels.ItemListRatios(*r.Query.Ratios)
+		list.Ratios = &ratios
+	}
+	if r.Query.Days != nil {
+		var days paramsmodels.ItemListDays
+		for _, day := range *r.Query.Days {
+			days = append(days, day.Format(time.DateOnly))
+		}
+		list.Days = &days
+	}
+	return params.ListItems200(list), nil
+}
+
+func (p *paramsHandler) HandleSearchItems(ctx context.Context, r paramsmodels.SearchItemsRequest) (*paramsmodels.SearchItemsResponse, error) {
+	echo := paramsmodels.SearchEcho{Offset: r.Query.Page.Offset, Size: r.Query.Page.Size}
+	if r.Query.Filter != nil {
+		echo.Status = &r.Query.Filter.Status
+		echo.Owner = r.Query.Filter.Owner
+		echo.MinRatio = r.Query.Filter.MinRatio
+	}
+	return params.SearchItems200(echo), nil
+}
+
+func newParamsServer() *httptest.Server {
+	router := chi.NewRouter()
+	h := &paramsHandler{}
+	params.NewHandler(h, h, h).AddRoutes(router)
+	return httptest.NewServer(router)
+}
+
+func TestTypedPathParams(t *testing.T) {
+	server := newParamsServer()
+	defer server.Close()
+
+	t.Run("200 parsed", func(t *testing.T) {
+		resp, err := http.Get(server.URL +
+			"/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 42, body["id"])
+		assert.Equal(t, "6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a", body["uid"])
+		assert.Equal(t, true, body["flag"])
+		assert.EqualValues(t, 1.5, body["ratio"])
+		assert.Equal(t, "2024-02-29", body["day"])
+		assert.Equal(t, "2024-02-29T10:00:00Z", body["at"])
+		assert.Equal(t, "13.42", body["amount"])
+	})
+
+	t.Run("200 zero values", func(t *testing.T) {
+		resp, err := http.Get(server.URL +
+			"/items/1/00000000-0000-0000-0000-000000000000/false/0/2024-02-29/2024-02-29T10:00:00Z/0")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000000", body["uid"])
+		assert.EqualValues(t, 0, body["ratio"])
+		assert.Equal(t, "0", body["amount"])
+	})
+
+	for _, tc := range []struct {
+		name string
+		path string
+	}{
+		{name: "400 integer", path: "/items/abc/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42"},
+		{name: "400 minimum", path: "/items/0/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42"},
+		{name: "400 uuid", path: "/items/42/not-a-uuid/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42"},
+		{name: "400 boolean", path: "/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/yes/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42"},
+		{name: "400 number", path: "/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/x/2024-02-29/2024-02-29T10:00:00Z/13.42"},
+		{name: "400 date", path: "/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-30/2024-02-29T10:00:00Z/13.42"},
+		{name: "400 date-time", path: "/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/yesterday/13.42"},
+		{name: "400 decimal", path: "/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/ten"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tc.path)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var body map[string]string
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, body["error"])
+		})
+	}
+}
+
+func TestArrayQueryParams(t *testing.T) {
+	server := newParamsServer()
+	defer server.Close()
+
+	t.Run("200 parsed", func(t *testing.T) {
+		resp, err := http.Get(server.URL +
+			"/items?tag=a&tag=b&ids=1,2,3&ratios=1.5%202.5&days=2024-02-28|2024-02-29")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"a", "b"}, body["tags"])
+		assert.Equal(t, []any{1.0, 2.0, 3.0}, body["ids"])
+		assert.Equal(t, []any{1.5, 2.5}, body["ratios"])
+		assert.Equal(t, []any{"2024-02-28", "2024-02-29"}, body["days"])
+	})
+
+	t.Run("200 optional omitted", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/items?tag=a")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"a"}, body["tags"])
+		assert.NotContains(t, body, "ids")
+	})
+
+	for _, tc := range []struct {
+		name  string
+		query string
+	}{
+		{name: "400 required", query: "ids=1,2"},
+		{name: "400 integer item", query: "tag=a&ids=1,x"},
+		{name: "400 unique", query: "tag=a&ids=1,1"},
+		{name: "400 number item", query: "tag=a&ratios=1.5%20x"},
+		{name: "400 date item", query: "tag=a&days=2024-02-30"},
+		{name: "400 max items", query: "tag=a&days=2024-02-27|2024-02-28|2024-02-29"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + "/items?" + tc.query)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var body map[string]string
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, body["error"])
+		})
+	}
+}
+
+func TestObjectQueryParams(t *testing.T) {
+	server := newParamsServer()
+	defer server.Close()
+
+	t.Run("200 parsed", func(t *testing.T) {
+		resp, err := http.Get(server.URL +
+			"/items/search?filter[status]=open&filter[owner]=me&filter[min_ratio]=0.5&offset=10&size=20")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "open", body["status"])
+		assert.Equal(t, "me", body["owner"])
+		assert.EqualValues(t, 0.5, body["min_ratio"])
+		assert.EqualValues(t, 10, body["offset"])
+		assert.EqualValues(t, 20, body["size"])
+	})
+
+	t.Run("200 optional object omitted", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/items/search?size=5")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.NotContains(t, body, "status")
+		assert.EqualValues(t, 5, body["size"])
+	})
+
+	for _, tc := range []struct {
+		name  string
+		query string
+	}{
+		{name: "400 required object", query: "filter[status]=open"},
+		{name: "400 required property", query: "filter[owner]=me&size=5"},
+		{name: "400 required nested property", query: "offset=1"},
+		{name: "400 enum", query: "filter[status]=pending&size=5"},
+		{name: "400 number", query: "filter[status]=open&filter[min_ratio]=x&size=5"},
+		{name: "400 integer", query: "size=many"},
+		{name: "400 maximum", query: "size=51"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + "/items/search?" + tc.query)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var body map[string]string
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, body["error"])
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/params/paramsmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerServeHTTP(t *testing.T) {
+	h := &paramsHandler{}
+	server := httptest.NewServer(params.NewHandler(h, h, h))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{name: "200 under the base path", path: "/v1/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42", status: http.StatusOK},
+		{name: "404 without the base path", path: "/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42", status: http.StatusNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tc.path)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+		})
+	}
+
+	t.Run("client", func(t *testing.T) {
+		tags := []string{"a", "b"}
+		response, err := params.NewClient(server.URL+"/v1").ListItems(context.Background(), paramsmodels.ListItemsRequest{
+			Query: paramsmodels.ListItemsQueryParams{Tag: tags},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, paramsmodels.ItemListTags(tags), response.Response200.Body.Tags)
+	})
+}
+
+func TestOperationHTTPHandler(t *testing.T) {
+	h := &paramsHandler{}
+	handler := params.NewHandler(h, h, h).ListItemsHTTPHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/anywhere?tag=a", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"tags": ["a"]}`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/anywhere?ids=x", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestWithOperationMiddleware(t *testing.T) {
+	var seen []string
+	record := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				id, _ := params.OperationIDFromContext(r.Context())
+				seen = append(seen, name+" "+string(id))
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	deny := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+
+	h := &paramsHandler{}
+	handler := params.NewHandler(h, h, h,
+		params.WithOperationMiddleware(params.OperationListItems, record("outer")),
+		params.WithOperationMiddleware(params.OperationListItems, record("inner")),
+		params.WithOperationMiddleware(params.OperationSearchItems, deny),
+	)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name   string
+		path   string
+		status int
+		seen   []string
+	}{
+		{name: "in order with the operation id", path: "/v1/items?tag=a", status: http.StatusOK, seen: []string{"outer list-items", "inner list-items"}},
+		{name: "only on its operation", path: "/v1/items/search?page[offset]=0&page[size]=10", status: http.StatusUnauthorized},
+		{name: "no middleware", path: "/v1/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42", status: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			seen = nil
+			resp, err := http.Get(server.URL + tc.path)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, tc.seen, seen)
+		})
+	}
+
+	t.Run("operation handler", func(t *testing.T) {
+		seen = nil
+		recorder := httptest.NewRecorder()
+		handler.ListItemsHTTPHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?tag=a", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, []string{"outer list-items", "inner list-items"}, seen)
+	})
+}
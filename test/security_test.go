@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/security"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/security/securitymodels"
+	"github.com/stretchr/testify/assert"
+)
+
+func principals(ctx context.Context) string {
+	var names []string
+	for _, scheme := range []security.SecurityScheme{
+		security.SecuritySchemeAPIKeyHeader,
+		security.SecuritySchemeAPIKeyQuery,
+		security.SecuritySchemeBasicAuth,
+		security.SecuritySchemeBearerAuth,
+		security.SecuritySchemeOauth,
+		security.SecuritySchemeSessionCookie,
+	} {
+		if principal, ok := security.PrincipalFromContext(ctx, scheme); ok {
+			names = append(names, fmt.Sprintf("%s=%v", scheme, principal))
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+func TestSecurity(t *testing.T) {
+	th := security.NewTestHandler()
+	echo := func(ctx context.Context) securitymodels.Caller {
+		return securitymodels.Caller{Name: principals(ctx)}
+	}
+	th.GetMe.Func = func(ctx context.Context, r securitymodels.GetMeRequest) (*securitymodels.GetMeResponse, error) {
+		return security.GetMe200(echo(ctx)), nil
+	}
+	th.ListReports.Func = func(ctx context.Context, r securitymodels.ListReportsRequest) (*securitymodels.ListReportsResponse, error) {
+		return security.ListReports200(echo(ctx)), nil
+	}
+	th.AdminAction.Func = func(ctx context.Context, r securitymodels.AdminActionRequest) (*securitymodels.AdminActionResponse, error) {
+		return security.AdminAction200(echo(ctx)), nil
+	}
+	th.GetFeed.Func = func(ctx context.Context, r securitymodels.GetFeedRequest) (*securitymodels.GetFeedResponse, error) {
+		return security.GetFeed200(echo(ctx)), nil
+	}
+	th.GetStatus.Returns(security.GetStatus200())
+
+	check := func(want string) func(ctx context.Context, credential string) (any, error) {
+		return func(ctx context.Context, credential string) (any, error) {
+			if credential != want {
+				return nil, errors.New("invalid credential")
+			}
+			return "user-" + credential, nil
+		}
+	}
+	th.BearerAuthAuthenticator.Func = check("token")
+	th.APIKeyHeaderAuthenticator.Func = check("header-key")
+	th.APIKeyQueryAuthenticator.Func = check("query-key")
+	th.SessionCookieAuthenticator.Func = check("session")
+	th.BasicAuthAuthenticator.Func = func(ctx context.Context, username string, password string) (any, error) {
+		if password != "secret" {
+			return nil, errors.New("invalid password")
+		}
+		return username, nil
+	}
+	th.OauthAuthenticator.Func = func(ctx context.Context, token string, scopes []string) (any, error) {
+		switch token {
+		case "token":
+			return strings.Join(scopes, ","), nil
+		case "unscoped":
+			return nil, &security.SecurityError{StatusCode: http.StatusForbidden}
+		default:
+			return nil, errors.New("invalid token")
+		}
+	}
+
+	server := httptest.NewServer(th)
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name      string
+		method    string
+		path      string
+		header    http.Header
+		basicAuth bool
+		status    int
+		caller    string
+	}{
+		{name: "bearer from the spec", path: "/me", header: http.Header{"Authorization": {"Bearer token"}}, status: http.StatusOK, caller: "bearer-auth=user-token"},
+		{name: "bearer scheme is case insensitive", path: "/me", header: http.Header{"Authorization": {"bearer token"}}, status: http.StatusOK, caller: "bearer-auth=user-token"},
+		{name: "bearer missing", path: "/me", status: http.StatusUnauthorized},
+		{name: "bearer rejected", path: "/me", header: http.Header{"Authorization": {"Bearer other"}}, status: http.StatusUnauthorized},
+		{name: "first alternative", path: "/reports", header: http.Header{"X-Api-Key": {"header-key"}}, status: http.StatusOK, caller: "api-key-header=user-header-key"},
+		{name: "second alternative", path: "/reports?api_key=query-key", header: http.Header{"X-Api-Key": {"wrong"}}, status: http.StatusOK, caller: "api-key-query=user-query-key"},
+		{name: "no alternative", path: "/reports?api_key=wrong", status: http.StatusUnauthorized},
+		{name: "operation overrides the spec", path: "/reports", header: http.Header{"Authorization": {"Bearer token"}}, status: http.StatusUnauthorized},
+		{name: "both schemes", method: http.MethodPost, path: "/admin", basicAuth: true, header: http.Header{"Cookie": {"session=session"}}, status: http.StatusOK, caller: "basic-auth=admin session-cookie=user-session"},
+		{name: "one scheme of two", method: http.MethodPost, path: "/admin", basicAuth: true, status: http.StatusUnauthorized},
+		{name: "scopes", path: "/feed", header: http.Header{"Authorization": {"Bearer token"}}, status: http.StatusOK, caller: "oauth=feed:read"},
+		{name: "anonymous alternative", path: "/feed", status: http.StatusOK},
+		{name: "invalid credentials are not anonymous", path: "/feed", header: http.Header{"Authorization": {"Bearer other"}}, status: http.StatusUnauthorized},
+		{name: "authenticator status", path: "/feed", header: http.Header{"Authorization": {"Bearer unscoped"}}, status: http.StatusForbidden},
+		{name: "security: [] override", path: "/status", status: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			request, err := http.NewRequest(method, server.URL+tc.path, nil)
+			assert.NoError(t, err)
+			for name, values := range tc.header {
+				request.Header[name] = values
+			}
+			if tc.basicAuth {
+				request.SetBasicAuth("admin", "secret")
+			}
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+			if tc.caller == "" {
+				return
+			}
+
+			var caller securitymodels.Caller
+			err = json.NewDecoder(resp.Body).Decode(&caller)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.caller, caller.Name)
+		})
+	}
+
+	t.Run("authenticator mocks accept by default", func(t *testing.T) {
+		th := security.NewTestHandler()
+		th.GetMe.Returns(security.GetMe200(securitymodels.Caller{Name: "anyone"}))
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/me", nil)
+		request.Header.Set("Authorization", "Bearer anything")
+		th.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/servemux/generated/params"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/servemux/generated/params/paramsmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeMuxRoutes(t *testing.T) {
+	th := params.NewTestHandler()
+	th.GetItem.Func = func(ctx context.Context, r paramsmodels.GetItemRequest) (*paramsmodels.GetItemResponse, error) {
+		return params.GetItem200(paramsmodels.Item{ID: r.Path.ID, UID: r.Path.UID.String(), Flag: r.Path.Flag}), nil
+	}
+	mux := http.NewServeMux()
+	th.AddRoutes(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	const item = "/items/42/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42"
+
+	t.Run("200 path values", func(t *testing.T) {
+		resp, err := http.Get(server.URL + item)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 42, body["id"])
+		assert.Equal(t, "6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a", body["uid"])
+
+		calls := th.GetItem.Calls()
+		assert.Len(t, calls, 1)
+		assert.Equal(t, "13.42", calls[0].Path.Amount.String())
+	})
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{name: "400 invalid path value", method: http.MethodGet, path: "/items/0/6f1c1a2e-8a59-4b3e-9c4d-1f2e3d4c5b6a/true/1.5/2024-02-29/2024-02-29T10:00:00Z/13.42", status: http.StatusBadRequest},
+		{name: "404 missing segment", method: http.MethodGet, path: "/items/42", status: http.StatusNotFound},
+		{name: "405 method", method: http.MethodDelete, path: item, status: http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(tc.method, server.URL+tc.path, nil)
+			assert.NoError(t, err)
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+		})
+	}
+
+	t.Run("ServeHTTP under the base path", func(t *testing.T) {
+		server := httptest.NewServer(th)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/v1" + item)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = http.Get(server.URL + item)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/telemetry/generated/validation"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/telemetry/generated/validation/validationmodels"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	th := validation.NewTestHandler(
+		validation.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		validation.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	th.CreateSignup.Returns(validation.CreateSignup200(validationmodels.Signup{Email: "a@example.com"}))
+	server := httptest.NewServer(th)
+	defer server.Close()
+
+	resp := postJSON(t, server.URL+"/signups", `{"email":"a@example.com"}`)
+	resp.Body.Close()
+	resp = postJSON(t, server.URL+"/signups", `{"email":"not an email"}`)
+	resp.Body.Close()
+	th.CreateSignup.Returns(nil)
+	resp = postJSON(t, server.URL+"/signups", `{"email":"a@example.com"}`)
+	resp.Body.Close()
+	resp, err := http.Post(server.URL+"/signups", "text/plain", strings.NewReader("a@example.com"))
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 4)
+	for i, status := range []int{200, 400, 500, 415} {
+		span := ended[i]
+		assert.Equal(t, "create-signup", span.Name())
+		assert.Contains(t, span.Attributes(), attribute.String("http.route", "/signups"))
+		assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", status))
+	}
+	assert.Contains(t, ended[1].Attributes(), attribute.Bool("validation.failed", true))
+	assert.Len(t, ended[1].Events(), 1)
+	assert.Equal(t, "exception", ended[1].Events()[0].Name)
+	assert.Equal(t, codes.Unset, ended[1].Status().Code)
+	assert.Equal(t, codes.Error, ended[2].Status().Code)
+
+	var metrics metricdata.ResourceMetrics
+	err = reader.Collect(context.Background(), &metrics)
+	assert.NoError(t, err)
+	assert.Len(t, metrics.ScopeMetrics, 1)
+	scope := metrics.ScopeMetrics[0]
+	assert.Equal(t, "github.com/sintoniastrategy/validgo-gen/internal/usage/telemetry/generated/validation", scope.Scope.Name)
+	for _, m := range scope.Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Histogram[float64]:
+			assert.Equal(t, "http.server.request.duration", m.Name)
+			assert.Len(t, data.DataPoints, 4)
+		case metricdata.Sum[int64]:
+			assert.Equal(t, "http.server.validation.failures", m.Name)
+			assert.Len(t, data.DataPoints, 1)
+			assert.Equal(t, int64(1), data.DataPoints[0].Value)
+			operation, _ := data.DataPoints[0].Attributes.Value("operation.id")
+			assert.Equal(t, "create-signup", operation.AsString())
+		default:
+			t.Errorf("unexpected metric %s", m.Name)
+		}
+	}
+	assert.Len(t, scope.Metrics, 2)
+}
@@ -5,18 +5,18 @@ package apimodels
 import "time"
 
 type CreatePathParams struct {
-	Param string `json:"param" validate:"required"`
+	Param string `json:"param"`
 }
 type CreateQueryParams struct {
-	Count string `json:"count" validate:"required"`
+	Count string `json:"count"`
 }
 type CreateHeaders struct {
-	IdempotencyKey string     `json:"Idempotency-Key" validate:"required,min=1,max=100"`
+	IdempotencyKey string     `json:"Idempotency-Key" validate:"min=1,max=100"`
 	OptionalHeader *time.Time `json:"Optional-Header,omitempty" validate:"omitempty"`
 }
 type CreateCookies struct {
 	CookieParam         *string `json:"cookie-param,omitempty" validate:"omitempty,min=10,max=15"`
-	RequiredCookieParam string  `json:"required-cookie-param" validate:"required,min=10,max=15"`
+	RequiredCookieParam string  `json:"required-cookie-param" validate:"min=10,max=15"`
 }
 type CreateRequestBodyArrayField []string
 type CreateRequestBodyObjectArrayItem struct {
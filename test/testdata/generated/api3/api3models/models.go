@@ -14,7 +14,7 @@ type ListResourcesResponse struct {
 	Response200 *ListResourcesResponse200
 }
 type DeleteResourcePathParams struct {
-	ID string `json:"id" validate:"required"`
+	ID string `json:"id"`
 }
 type DeleteResourceRequest struct {
 	Path DeleteResourcePathParams
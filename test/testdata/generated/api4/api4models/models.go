@@ -5,7 +5,7 @@ package api4models
 import "github.com/sintoniastrategy/validgo-gen/test/testdata/generated/def/defmodels"
 
 type GetResourcePathParams struct {
-	ID string `json:"id" validate:"required"`
+	ID string `json:"id"`
 }
 type GetResourceRequest struct {
 	Path GetResourcePathParams
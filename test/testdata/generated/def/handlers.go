@@ -4,6 +4,7 @@ package def
 
 import (
 	"encoding/json"
+	"strings"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-faster/errors"
 	"github.com/go-playground/validator/v10"
@@ -27,65 +28,192 @@ func containsNull(data json.RawMessage) bool {
 	return temp == nil
 }
 func ValidateErrorResponseJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"code": true, "message": true}
+	requiredFields := []string{"code", "message"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateNewResourseRequestJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"name": true}
+	requiredFields := []string{"name"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
 }
 func ValidateNewResourseResponseJSON(jsonData json.RawMessage) error {
-	requiredFields := map[string]bool{"count": true, "name": true, "param": true}
+	requiredFields := []string{"count", "name", "param"}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
 		return err
 	}
+	var errs ValidationError
 	var val json.RawMessage
 	var exists bool
-	for field := range requiredFields {
+	for _, field := range requiredFields {
 		val, exists = obj[field]
 		if !exists {
-			return errors.New("field " + field + " is required")
+			errs.add(jsonPointer(field), "required", "field "+field+" is required")
+			continue
 		}
 		if !nullableFields[field] && containsNull(val) {
-			return errors.New("field " + field + " cannot be null")
+			errs.add(jsonPointer(field), "null", "field "+field+" cannot be null")
 		}
 	}
-	return nil
+	return errs.Err()
+}
+
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+type ValidationError struct {
+	Errors []FieldError
+	causes []error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, field := range e.Errors {
+		if field.Pointer == "" {
+			messages = append(messages, field.Message)
+			continue
+		}
+		messages = append(messages, field.Pointer+": "+field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+func (e *ValidationError) Unwrap() []error {
+	return e.causes
+}
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(tokens ...string) string {
+	var pointer strings.Builder
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+		pointer.WriteString("/" + jsonPointerEscaper.Replace(token))
+	}
+	return pointer.String()
+}
+func (e *ValidationError) add(pointer string, rule string, message string) {
+	for _, field := range e.Errors {
+		if field.Pointer == pointer {
+			return
+		}
+	}
+	e.Errors = append(e.Errors, FieldError{Pointer: pointer, Rule: rule, Message: message})
+}
+func (e *ValidationError) addParam(name string, err error) {
+	var field *FieldError
+	if errors.As(err, &field) {
+		e.add(jsonPointer(name), field.Rule, field.Message)
+		return
+	}
+	e.add(jsonPointer(name), "type", err.Error())
+}
+func (e *ValidationError) merge(token string, err error) {
+	if err == nil {
+		return
+	}
+	prefix := jsonPointer(token)
+	var nested *ValidationError
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &nested):
+		for _, field := range nested.Errors {
+			e.add(prefix+field.Pointer, field.Rule, field.Message)
+		}
+		e.causes = append(e.causes, nested.causes...)
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			rule, message := validatorRule(fieldError)
+			e.add(prefix+namespacePointer(fieldError.Namespace()), rule, message)
+		}
+	case errors.As(err, &typeError):
+		pointer := prefix
+		if typeError.Field != "" {
+			pointer += jsonPointer(strings.Split(typeError.Field, ".")...)
+		}
+		e.add(pointer, "type", "value of type "+typeError.Value+" is not "+typeError.Type.String())
+	default:
+		e.add(prefix, "invalid", err.Error())
+		e.causes = append(e.causes, err)
+	}
+}
+func namespacePointer(namespace string) string {
+	if !strings.HasPrefix(namespace, "[") {
+		index := strings.IndexAny(namespace, ".[")
+		if index < 0 {
+			return ""
+		}
+		namespace = strings.TrimPrefix(namespace[index:], ".")
+	}
+	tokens := strings.FieldsFunc(namespace, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	})
+	filtered := tokens[:0]
+	for _, token := range tokens {
+		if token != "-" {
+			filtered = append(filtered, token)
+		}
+	}
+	return jsonPointer(filtered...)
+}
+func validatorRule(fieldError validator.FieldError) (string, string) {
+	rule := fieldError.Tag()
+	if fieldError.Param() != "" {
+		rule += "=" + fieldError.Param()
+	}
+	return fieldError.Tag(), "value must satisfy " + rule
 }
@@ -0,0 +1,22 @@
+// Package api3impl implements the operations of api3.yaml.
+// Methods of new operations are appended by the generator; it never changes existing code.
+package api3impl
+
+import (
+	"context"
+
+	"github.com/sintoniastrategy/validgo-gen/test/testdata/generated/api3"
+	"github.com/sintoniastrategy/validgo-gen/test/testdata/generated/api3/api3models"
+)
+
+// Handler implements the handler of every operation, wire it with
+// api3.NewHandler.
+type Handler struct{}
+
+func (h *Handler) HandleListResources(ctx context.Context, r api3models.ListResourcesRequest) (*api3models.ListResourcesResponse, error) {
+	return nil, api3.ErrNotImplemented
+}
+
+func (h *Handler) HandleDeleteResource(ctx context.Context, r api3models.DeleteResourceRequest) (*api3models.DeleteResourceResponse, error) {
+	return nil, api3.ErrNotImplemented
+}
@@ -0,0 +1,399 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/validation"
+	"github.com/sintoniastrategy/validgo-gen/internal/usage/generated/validation/validationmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+type validationHandler struct{}
+
+func (v *validationHandler) HandlePutArticle(ctx context.Context, r validationmodels.PutArticleRequest) (*validationmodels.PutArticleResponse, error) {
+	return validation.PutArticle200(r.Body), nil
+}
+
+func (v *validationHandler) HandlePutSettings(ctx context.Context, r validationmodels.PutSettingsRequest) (*validationmodels.PutSettingsResponse, error) {
+	return validation.PutSettings200(r.Body), nil
+}
+
+func (v *validationHandler) HandlePutLimits(ctx context.Context, r validationmodels.PutLimitsRequest) (*validationmodels.PutLimitsResponse, error) {
+	return validation.PutLimits200(r.Body), nil
+}
+
+func (v *validationHandler) HandleCreateSignup(ctx context.Context, r validationmodels.CreateSignupRequest) (*validationmodels.CreateSignupResponse, error) {
+	return validation.CreateSignup200(r.Body), nil
+}
+
+func newValidationServer() *httptest.Server {
+	router := chi.NewRouter()
+	h := &validationHandler{}
+	validation.NewHandler(h, h, h, h).AddRoutes(router)
+	return httptest.NewServer(router)
+}
+
+func putJSON(t *testing.T, url string, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+func TestPatternValidation(t *testing.T) {
+	server := newValidationServer()
+	defer server.Close()
+
+	t.Run("200 matching", func(t *testing.T) {
+		resp := putJSON(t, server.URL+"/articles/hello-world?lang=en",
+			`{"title":"Hello","sku":"ABC-1234","tags":["#go","#api"]}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		path    string
+		body    string
+		pattern string
+	}{
+		{
+			name:    "400 path",
+			path:    "/articles/Hello_World",
+			body:    `{"title":"Hello","sku":"ABC-1234"}`,
+			pattern: `^[a-z0-9]+(-[a-z0-9]+)*$`,
+		},
+		{
+			name:    "400 query",
+			path:    "/articles/hello?lang=eng",
+			body:    `{"title":"Hello","sku":"ABC-1234"}`,
+			pattern: `^[a-z]{2}$`,
+		},
+		{
+			name:    "400 ref",
+			path:    "/articles/hello",
+			body:    `{"title":"Hello","sku":"abc-1234"}`,
+			pattern: `^[A-Z]{3}-\d{4}$`,
+		},
+		{
+			name:    "400 array item",
+			path:    "/articles/hello",
+			body:    `{"title":"Hello","sku":"ABC-1234","tags":["#go","api"]}`,
+			pattern: `^#\w+$`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := putJSON(t, server.URL+tc.path, tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var body map[string]string
+			err := json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Contains(t, body["error"], "value must match pattern "+tc.pattern)
+		})
+	}
+}
+
+func TestNumericConstraints(t *testing.T) {
+	server := newValidationServer()
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name   string
+		body   string
+		status int
+		tag    string
+	}{
+		{name: "200 all valid", body: `{"title":"Hello","sku":"ABC-1234","price":"19.99","quantity":10,"rating":4.9}`, status: http.StatusOK},
+		{name: "200 float tolerance", body: `{"title":"Hello","sku":"ABC-1234","rating":0.3}`, status: http.StatusOK},
+		{name: "200 decimal trailing zeros", body: `{"title":"Hello","sku":"ABC-1234","price":"20.100"}`, status: http.StatusOK},
+		{name: "400 exclusive minimum", body: `{"title":"Hello","sku":"ABC-1234","quantity":0}`, status: http.StatusBadRequest, tag: "gt=0"},
+		{name: "400 exclusive maximum", body: `{"title":"Hello","sku":"ABC-1234","rating":5}`, status: http.StatusBadRequest, tag: "lt=5"},
+		{name: "400 integer multipleOf", body: `{"title":"Hello","sku":"ABC-1234","quantity":7}`, status: http.StatusBadRequest, tag: "multipleof=5"},
+		{name: "400 number multipleOf", body: `{"title":"Hello","sku":"ABC-1234","rating":0.35}`, status: http.StatusBadRequest, tag: "multipleof=0.1"},
+		{name: "400 decimal multipleOf", body: `{"title":"Hello","sku":"ABC-1234","price":"19.995"}`, status: http.StatusBadRequest, tag: "multipleof=0.01"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := putJSON(t, server.URL+"/articles/hello", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+			if tc.tag == "" {
+				return
+			}
+
+			var errBody map[string]string
+			err := json.NewDecoder(resp.Body).Decode(&errBody)
+			assert.NoError(t, err)
+			assert.Contains(t, errBody["error"], tc.tag)
+		})
+	}
+}
+
+func TestAdditionalProperties(t *testing.T) {
+	server := newValidationServer()
+	defer server.Close()
+
+	t.Run("200 catch-all", func(t *testing.T) {
+		resp := putJSON(t, server.URL+"/settings",
+			`{"name":"main","labels":{"env":"prod"},"limits":{"cpu":2},"beta":{"enabled":true}}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body validationmodels.Settings
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, "main", body.Name)
+		assert.Equal(t, validationmodels.SettingsLabels{"env": "prod"}, *body.Labels)
+		assert.Equal(t, validationmodels.Limits{"cpu": 2}, *body.Limits)
+		assert.Equal(t, map[string]validationmodels.Feature{"beta": {Enabled: true}}, body.AdditionalProperties)
+	})
+
+	t.Run("200 untyped map", func(t *testing.T) {
+		resp := putJSON(t, server.URL+"/settings", `{"name":"main","metadata":{"owner":"ops","replicas":3,"tags":["a"]}}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body validationmodels.Settings
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, validationmodels.SettingsMetadata{"owner": "ops", "replicas": 3.0, "tags": []any{"a"}}, *body.Metadata)
+	})
+
+	t.Run("200 map body", func(t *testing.T) {
+		resp := putJSON(t, server.URL+"/limits", `{"cpu":2,"memory":512}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]int
+		err := json.NewDecoder(resp.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"cpu": 2, "memory": 512}, body)
+	})
+
+	for _, tc := range []struct {
+		name string
+		url  string
+		body string
+	}{
+		{name: "400 map value validator", url: "/settings", body: `{"name":"main","labels":{"env":""}}`},
+		{name: "400 map value type", url: "/settings", body: `{"name":"main","limits":{"cpu":"two"}}`},
+		{name: "400 catch-all required", url: "/settings", body: `{"name":"main","beta":{}}`},
+		{name: "400 closed object", url: "/settings", body: `{"name":"main","beta":{"enabled":true,"extra":1}}`},
+		{name: "400 map body value", url: "/limits", body: `{"cpu":-1}`},
+		{name: "400 map body size", url: "/limits", body: `{"a":1,"b":2,"c":3,"d":4}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := putJSON(t, server.URL+tc.url, tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestStrictUnknownFields(t *testing.T) {
+	server := newValidationServer()
+	defer server.Close()
+
+	t.Run("200 declared fields", func(t *testing.T) {
+		resp := postJSON(t, server.URL+"/signups",
+			`{"email":"a@example.com","profile":{"display/name":"A"},"contacts":[{"kind":"phone"}]}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		body    string
+		pointer string
+	}{
+		{name: "top level", body: `{"email":"a@example.com","emial":"b@example.com"}`, pointer: "/emial"},
+		{name: "nested object", body: `{"email":"a@example.com","profile":{"display/nmae":"A"}}`, pointer: "/profile/display~1nmae"},
+		{name: "array item", body: `{"email":"a@example.com","contacts":[{"kind":"phone"},{"knd":"fax"}]}`, pointer: "/contacts/1/knd"},
+	} {
+		t.Run("400 "+tc.name, func(t *testing.T) {
+			resp := postJSON(t, server.URL+"/signups", tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var body map[string]string
+			err := json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Contains(t, body["error"], "/body"+tc.pointer+": unknown field")
+		})
+	}
+
+	t.Run("client ignores unknown response fields", func(t *testing.T) {
+		response, err := validation.DecodeCreateSignupResponse(&http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"email":"a@example.com","plan":"pro","profile":{"avatar":"a.png"}}`)),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "a@example.com", response.Response200.Body.Email)
+
+		_, err = validation.DecodeCreateSignupResponse(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"plan":"pro"}`)),
+		})
+		assert.ErrorContains(t, err, "/body/email: field email is required")
+	})
+}
+
+func TestValidationErrorAggregation(t *testing.T) {
+	router := chi.NewRouter()
+	h := &validationHandler{}
+	validation.NewHandler(h, h, h, h, validation.WithValidationErrorHandler(validation.ProblemDetailsHandler)).AddRoutes(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		errors []validation.FieldError
+	}{
+		{
+			name:   "params and missing body field",
+			method: http.MethodPut,
+			path:   "/articles/Bad_Slug?lang=english",
+			body:   `{"sku":"ABC-1234"}`,
+			errors: []validation.FieldError{
+				{Pointer: "/path/slug", Rule: "pattern", Message: "value must match pattern ^[a-z0-9]+(-[a-z0-9]+)*$"},
+				{Pointer: "/query/lang", Rule: "pattern", Message: "value must match pattern ^[a-z]{2}$"},
+				{Pointer: "/body/title", Rule: "required", Message: "field title is required"},
+			},
+		},
+		{
+			name:   "body struct validators",
+			method: http.MethodPut,
+			path:   "/articles/hello",
+			body:   `{"title":"Hello","sku":"ABC-1234","quantity":0,"rating":5}`,
+			errors: []validation.FieldError{
+				{Pointer: "/body/quantity", Rule: "gt", Message: "value must satisfy gt=0"},
+				{Pointer: "/body/rating", Rule: "lt", Message: "value must satisfy lt=5"},
+			},
+		},
+		{
+			name:   "body type mismatch",
+			method: http.MethodPut,
+			path:   "/articles/hello",
+			body:   `{"title":"Hello","sku":"ABC-1234","quantity":"ten"}`,
+			errors: []validation.FieldError{
+				{Pointer: "/body/quantity", Rule: "type", Message: "value of type string is not int"},
+			},
+		},
+		{
+			name:   "layer-1 failures",
+			method: http.MethodPost,
+			path:   "/signups",
+			body:   `{"email":null,"contacts":[{"knd":"fax"}]}`,
+			errors: []validation.FieldError{
+				{Pointer: "/body/email", Rule: "null", Message: "field email cannot be null"},
+				{Pointer: "/body/contacts/0/knd", Rule: "unknown", Message: "unknown field"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, bytes.NewBufferString(tc.body))
+			assert.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+
+			var problem validation.ProblemDetails
+			err = json.NewDecoder(resp.Body).Decode(&problem)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, problem.Status)
+			path, _, _ := strings.Cut(tc.path, "?")
+			assert.Equal(t, path, problem.Instance)
+			assert.ElementsMatch(t, tc.errors, problem.Errors)
+		})
+	}
+}
+
+// driftingHandler returns responses that no longer match the spec.
+type driftingHandler struct {
+	validationHandler
+}
+
+func (d *driftingHandler) HandlePutArticle(ctx context.Context, r validationmodels.PutArticleRequest) (*validationmodels.PutArticleResponse, error) {
+	r.Body.Sku = validationmodels.Sku(strings.ToLower(string(r.Body.Sku)))
+	return validation.PutArticle200(r.Body), nil
+}
+
+func (d *driftingHandler) HandlePutLimits(ctx context.Context, r validationmodels.PutLimitsRequest) (*validationmodels.PutLimitsResponse, error) {
+	r.Body["extra"] = 1
+	return validation.PutLimits200(r.Body), nil
+}
+
+func TestWithResponseValidation(t *testing.T) {
+	newServer := func(opts ...validation.Option) *httptest.Server {
+		router := chi.NewRouter()
+		h := &driftingHandler{}
+		validation.NewHandler(h, h, h, h, opts...).AddRoutes(router)
+		return httptest.NewServer(router)
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+		resp := putJSON(t, server.URL+"/articles/hello", `{"title":"Hello","sku":"ABC-1234"}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	server := newServer(validation.WithResponseValidation())
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name    string
+		path    string
+		body    string
+		message string
+	}{
+		{
+			name:    "500 struct",
+			path:    "/articles/hello",
+			body:    `{"title":"Hello","sku":"ABC-1234"}`,
+			message: "PutArticle: invalid 200 response: /body/sku: value must match pattern ^[A-Z]{3}-\\d{4}$",
+		},
+		{
+			name:    "500 map",
+			path:    "/limits",
+			body:    `{"a":1,"b":2,"c":3}`,
+			message: "PutLimits: invalid 200 response: /body: value must satisfy max=3",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := putJSON(t, server.URL+tc.path, tc.body)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+			var body map[string]string
+			err := json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.message, body["error"])
+		})
+	}
+
+	t.Run("200 valid", func(t *testing.T) {
+		resp := putJSON(t, server.URL+"/limits", `{"a":1}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}